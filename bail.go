@@ -0,0 +1,45 @@
+package zvalidate
+
+// Bail stops adding errors for a key once it has an error.
+//
+// This applies to the given keys, or to all keys if none are given. Any later
+// validations for the key won't add errors, and Check() won't run at all. For
+// example, this will only report "must be set" for an empty email:
+//
+//	v.Bail("email")
+//	v.Required("email", email)
+//	v.Email("email", email)
+//	v.Len("email", email, 0, 255)
+//
+// Use Failed() to skip your own expensive checks.
+func (v *Validator) Bail(keys ...string) {
+	st := v.st()
+	if len(keys) == 0 {
+		st.bail = true
+		return
+	}
+
+	if st.bailKeys == nil {
+		st.bailKeys = make(map[string]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		st.bailKeys[k] = struct{}{}
+	}
+}
+
+// Failed reports if there are any errors for this key.
+func (v *Validator) Failed(key string) bool {
+	return len(v.Errors[key]) > 0
+}
+
+// bailed reports if no more errors should be added for this key.
+func (v *Validator) bailed(key string) bool {
+	if v.state == nil || !v.Failed(key) {
+		return false
+	}
+	if v.state.bail {
+		return true
+	}
+	_, ok := v.state.bailKeys[key]
+	return ok
+}
@@ -0,0 +1,71 @@
+package zvalidate
+
+import (
+	"context"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestBail(t *testing.T) {
+	tests := []struct {
+		bail []string
+		want string
+	}{
+		{nil, "email: must be set, must be a valid email address, must be longer than 5 characters.\n" +
+			"name: must be longer than 5 characters, must be a whole number.\n"},
+		{[]string{}, "email: must be set.\nname: must be longer than 5 characters.\n"},
+		{[]string{"email"}, "email: must be set.\nname: must be longer than 5 characters, must be a whole number.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			v := New()
+			if tt.bail != nil {
+				v.Bail(tt.bail...)
+			}
+
+			v.Required("email", []string{""})
+			v.Email("email", "x")
+			v.Len("email", "x", 5, 0)
+			v.Len("name", "x", 5, 0)
+			v.Integer("name", "x")
+
+			if d := ztest.Diff(v.String(), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestBailCheck(t *testing.T) {
+	v := New()
+	v.Bail()
+	v.Required("email", "")
+
+	ran := false
+	v.Check(context.Background(), "email", func(context.Context) (string, error) {
+		ran = true
+		return "must be unique", nil
+	})
+	if ran {
+		t.Error("Check() ran")
+	}
+	if !v.Failed("email") {
+		t.Error("Failed(email) is false")
+	}
+	if v.Failed("other") {
+		t.Error("Failed(other) is true")
+	}
+
+	v.Go(func(v *Validator) {
+		v.Append("go", "first")
+		v.Append("go", "second")
+	})
+	v.Wait()
+
+	want := "email: must be set.\ngo: first.\n"
+	if d := ztest.Diff(v.String(), want); d != "" {
+		t.Error(d)
+	}
+}
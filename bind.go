@@ -0,0 +1,216 @@
+package zvalidate
+
+import (
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+	"unicode"
+)
+
+// NewFormRequest creates a new Form from the request's form values, with a new
+// Validator.
+//
+// This calls ParseForm() on the request, and returns the error from that.
+func NewFormRequest(r *http.Request) (*Form, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	v := New()
+	return NewForm(r.Form, &v), nil
+}
+
+// The methods below mirror the validators on Validator, but only take the key
+// and get the value from the form. A new Validator is created if Validator is
+// nil.
+
+func (f *Form) v() *Validator {
+	if f.Validator == nil {
+		v := New()
+		f.Validator = &v
+	}
+	return f.Validator
+}
+
+// Required validates that the key has a non-empty value.
+func (f *Form) Required(key string, message ...string) {
+	f.v().Required(key, f.Values[key], message...)
+}
+
+// Exclude validates that the value is not in the exclude list.
+func (f *Form) Exclude(key string, exclude []string, message ...string) string {
+	return f.v().Exclude(key, f.Values.Get(key), exclude, message...)
+}
+
+// Include validates that the value is in the include list.
+func (f *Form) Include(key string, include []string, message ...string) string {
+	r, _ := f.v().Include(key, f.Values.Get(key), include, message...).(string)
+	return r
+}
+
+// Range parses the value as an integer and validates the minimum and maximum.
+func (f *Form) Range(key string, min, max int64, message ...string) int64 {
+	n := len(f.v().Errors[key])
+	i := f.Integer(key)
+	if f.Values.Get(key) != "" && len(f.v().Errors[key]) == n {
+		f.v().Range(key, i, min, max, message...)
+	}
+	return i
+}
+
+// Len validates the character (rune) length of the value.
+func (f *Form) Len(key string, min, max int, message ...string) int {
+	return f.v().Len(key, f.Values.Get(key), min, max, message...)
+}
+
+// Domain parses the value as a domain.
+func (f *Form) Domain(key string, message ...string) []string {
+	return f.v().Domain(key, f.Values.Get(key), message...)
+}
+
+// Hostname parses the value as a hostname.
+func (f *Form) Hostname(key string, message ...string) []string {
+	return f.v().Hostname(key, f.Values.Get(key), message...)
+}
+
+// URL parses the value as an URL.
+func (f *Form) URL(key string, message ...string) *url.URL {
+	return f.v().URL(key, f.Values.Get(key), message...)
+}
+
+// URLLocal parses the value as an URL, also allowing local URLs.
+func (f *Form) URLLocal(key string, message ...string) *url.URL {
+	return f.v().URLLocal(key, f.Values.Get(key), message...)
+}
+
+// Email parses the value as an email address.
+func (f *Form) Email(key string, message ...string) mail.Address {
+	return f.v().Email(key, f.Values.Get(key), message...)
+}
+
+// IPv4 parses the value as an IPv4 address.
+func (f *Form) IPv4(key string, message ...string) net.IP {
+	return f.v().IPv4(key, f.Values.Get(key), message...)
+}
+
+// IP parses the value as an IPv4 or IPv6 address.
+func (f *Form) IP(key string, message ...string) net.IP {
+	return f.v().IP(key, f.Values.Get(key), message...)
+}
+
+// HexColor parses the value as a hex triplet.
+func (f *Form) HexColor(key string, message ...string) (uint8, uint8, uint8) {
+	return f.v().HexColor(key, f.Values.Get(key), message...)
+}
+
+// UTF8 validates that the value is valid UTF-8.
+func (f *Form) UTF8(key string, message ...string) {
+	f.v().UTF8(key, f.Values.Get(key), message...)
+}
+
+// Contains validates that the value only contains the given characters.
+func (f *Form) Contains(key string, ranges []*unicode.RangeTable, runes []rune, message ...string) {
+	f.v().Contains(key, f.Values.Get(key), ranges, runes, message...)
+}
+
+// Integer parses the value as an integer.
+func (f *Form) Integer(key string, message ...string) int64 {
+	return f.v().Integer(key, f.Values.Get(key), message...)
+}
+
+// Hex parses the value as a base-16 integer.
+func (f *Form) Hex(key string, message ...string) int64 {
+	return f.v().Hex(key, f.Values.Get(key), message...)
+}
+
+// Octal parses the value as a base-8 integer.
+func (f *Form) Octal(key string, message ...string) int64 {
+	return f.v().Octal(key, f.Values.Get(key), message...)
+}
+
+// Boolean parses the value as a boolean.
+func (f *Form) Boolean(key string, message ...string) bool {
+	return f.v().Boolean(key, f.Values.Get(key), message...)
+}
+
+// Date parses the value in the given date layout.
+func (f *Form) Date(key, layout string, message ...string) time.Time {
+	return f.v().Date(key, f.Values.Get(key), layout, message...)
+}
+
+// Phone parses the value as a phone number.
+func (f *Form) Phone(key string, message ...string) string {
+	return f.v().Phone(key, f.Values.Get(key), message...)
+}
+
+// PhoneInternational parses the value as a phone number with a country
+// prefix.
+func (f *Form) PhoneInternational(key string, message ...string) string {
+	return f.v().PhoneInternational(key, f.Values.Get(key), message...)
+}
+
+// Each calls fn for every value of a multi-valued field.
+//
+// The errors fn adds to v are added with the index as key; for example with:
+//
+//	f.Each("tags", func(v *zvalidate.Validator, value string) {
+//	    v.Len("", value, 0, 10)
+//	})
+//
+// the errors are added as "tags[0]", "tags[1]", etc. Any non-empty key is
+// added after the index ("tags[0].key").
+func (f *Form) Each(key string, fn func(v *Validator, value string)) {
+	each(f, key, func(v *Validator, value string) struct{} {
+		fn(v, value)
+		return struct{}{}
+	})
+}
+
+// Integers parses all values as an integer.
+func (f *Form) Integers(key string, message ...string) []int64 {
+	return each(f, key, func(v *Validator, value string) int64 { return v.Integer("", value, message...) })
+}
+
+// Booleans parses all values as a boolean.
+func (f *Form) Booleans(key string, message ...string) []bool {
+	return each(f, key, func(v *Validator, value string) bool { return v.Boolean("", value, message...) })
+}
+
+// Emails parses all values as an email address.
+func (f *Form) Emails(key string, message ...string) []mail.Address {
+	return each(f, key, func(v *Validator, value string) mail.Address { return v.Email("", value, message...) })
+}
+
+// Dates parses all values in the given date layout.
+func (f *Form) Dates(key, layout string, message ...string) []time.Time {
+	return each(f, key, func(v *Validator, value string) time.Time { return v.Date("", value, layout, message...) })
+}
+
+func each[T any](f *Form, key string, fn func(*Validator, string) T) []T {
+	vals := f.Values[key]
+	if len(vals) == 0 {
+		return nil
+	}
+
+	pv := f.v()
+	r := make([]T, 0, len(vals))
+	for i, val := range vals {
+		sub := New()
+		sub.msg = pv.msg
+		r = append(r, fn(&sub, val))
+		pv.SubPath(NewPath(key, i), &sub)
+	}
+	return r
+}
+
+// Unexpected adds an error for every key in the form that's not in allowed.
+func (f *Form) Unexpected(allowed ...string) {
+	f.v().Unexpected(f.Values, allowed...)
+}
+
+// Duplicate adds an error for every key that's submitted more than once.
+func (f *Form) Duplicate(keys ...string) {
+	f.v().Duplicate(f.Values, keys...)
+}
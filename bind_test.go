@@ -0,0 +1,92 @@
+package zvalidate
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestFormBind(t *testing.T) {
+	f := NewForm(url.Values{
+		"name":  {""},
+		"age":   {"150"},
+		"email": {"x"},
+		"ids":   {"1", "x", "3", "y"},
+		"dates": {"2020-01-01"},
+		"color": {"blue"},
+	}, nil)
+
+	f.Required("name")
+	age := f.Range("age", 0, 120)
+	f.Email("email")
+	ids := f.Integers("ids")
+	dates := f.Dates("dates", time.DateOnly)
+	f.Include("color", []string{"red", "green"})
+	f.Integers("missing")
+	f.Each("tags", func(v *Validator, value string) { t.Fatal("called") })
+
+	if age != 150 {
+		t.Errorf("age: %d", age)
+	}
+	if d := ztest.Diff(mustJSON(t, ids), `[1,0,3,0]`); d != "" {
+		t.Error(d)
+	}
+	if len(dates) != 1 || dates[0].Year() != 2020 {
+		t.Errorf("dates: %v", dates)
+	}
+
+	want := `
+age: must be 120 or lower.
+color: must be one of ‘red, green’.
+email: must be a valid email address.
+ids[1]: must be a whole number.
+ids[3]: must be a whole number.
+name: must be set.`
+	if d := ztest.Diff(f.Validator.String(), strings.TrimSpace(want)); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(mustJSON(t, f.Validator.Tree()["ids"]), `[null,["must be a whole number"],null,["must be a whole number"]]`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestFormBindRange(t *testing.T) {
+	f := NewForm(url.Values{"a": {"x"}, "b": {""}}, nil)
+	f.Range("a", 1, 2)
+	f.Range("b", 1, 2)
+
+	want := `a: must be a whole number.`
+	if d := ztest.Diff(f.Validator.String(), want); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestNewFormRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/?q=a", strings.NewReader("n=1&n=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	f, err := NewFormRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := ztest.Diff(mustJSON(t, f.Integers("n")), `[1,2]`); d != "" {
+		t.Error(d)
+	}
+	if f.Value("q") != "a" {
+		t.Errorf("q: %q", f.Value("q"))
+	}
+	if f.Validator.HasErrors() {
+		t.Error(f.Validator)
+	}
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("%zz"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = NewFormRequest(r)
+	if err == nil {
+		t.Error("err is nil")
+	}
+}
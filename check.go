@@ -0,0 +1,92 @@
+package zvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Check runs a validation which may need to do I/O, such as checking if an
+// email address is unique in the database.
+//
+// f should return a message if the validation failed, or an empty string if it
+// didn't. An error is an internal error (e.g. the database is down) rather
+// than a validation failure: it's not added as a message, but is returned from
+// ErrorOrNil() and InternalError() instead.
+//
+// Check returns when ctx is cancelled or its deadline passes, even if f
+// doesn't check the context, in which case ctx.Err() is recorded as an
+// internal error.
+//
+// For example:
+//
+//	v.Check(ctx, "email", func(ctx context.Context) (string, error) {
+//	    exists, err := emailExists(ctx, c.Email)
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    if exists {
+//	        return "must be unique", nil
+//	    }
+//	    return "", nil
+//	})
+//
+// Use Go() to run several checks concurrently. f is not run if Bail() is set
+// and key already has an error.
+func (v *Validator) Check(ctx context.Context, key string, f func(context.Context) (string, error)) {
+	if v.bailed(key) {
+		return
+	}
+	if err := ctx.Err(); err != nil {
+		v.addInternal(fmt.Errorf("zvalidate: check %q: %w", key, err))
+		return
+	}
+
+	type result struct {
+		msg string
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		msg, err := f(ctx)
+		ch <- result{msg, err}
+	}()
+
+	var r result
+	select {
+	case r = <-ch:
+	case <-ctx.Done():
+		select {
+		case r = <-ch:
+		default:
+			v.addInternal(fmt.Errorf("zvalidate: check %q: %w", key, ctx.Err()))
+			return
+		}
+	}
+
+	if r.err != nil {
+		v.addInternal(fmt.Errorf("zvalidate: check %q: %w", key, r.err))
+		return
+	}
+	if r.msg != "" {
+		v.Append(key, r.msg)
+	}
+}
+
+// InternalError gets all internal errors from Check(), or nil if there are
+// none.
+func (v *Validator) InternalError() error {
+	if v.state == nil {
+		return nil
+	}
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+	return errors.Join(v.state.internal...)
+}
+
+func (v *Validator) addInternal(err error) {
+	st := v.st()
+	st.mu.Lock()
+	st.internal = append(st.internal, err)
+	st.mu.Unlock()
+}
@@ -0,0 +1,154 @@
+package zvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestCheck(t *testing.T) {
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		ctx          func() (context.Context, context.CancelFunc)
+		f            func(context.Context) (string, error)
+		wantErrors   string
+		wantInternal string
+		wantIs       error
+	}{
+		{
+			"ok",
+			func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			func(context.Context) (string, error) { return "", nil },
+			"", "", nil,
+		},
+		{
+			"message",
+			func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			func(context.Context) (string, error) { return "must be unique", nil },
+			"email: must be unique.", "", nil,
+		},
+		{
+			"error",
+			func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			func(context.Context) (string, error) { return "", errDB },
+			"", `zvalidate: check "email": connection refused`, errDB,
+		},
+		{
+			"cancelled",
+			func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			func(context.Context) (string, error) { panic("should not run") },
+			"", `zvalidate: check "email": context canceled`, context.Canceled,
+		},
+		{
+			"deadline",
+			func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			func(context.Context) (string, error) {
+				time.Sleep(time.Second)
+				return "must be unique", nil
+			},
+			"", `zvalidate: check "email": context deadline exceeded`, context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			v := New()
+			v.Check(ctx, "email", tt.f)
+
+			if d := ztest.Diff(v.String(), tt.wantErrors); d != "" {
+				t.Error(d)
+			}
+
+			var have string
+			if err := v.InternalError(); err != nil {
+				have = err.Error()
+				if !errors.Is(v.ErrorOrNil(), tt.wantIs) {
+					t.Errorf("ErrorOrNil() is not the internal error: %v", v.ErrorOrNil())
+				}
+				if As(v.ErrorOrNil()) == nil {
+					t.Error("ErrorOrNil() is not a Validator")
+				}
+				if v.Code() != 500 {
+					t.Errorf("Code(): %d", v.Code())
+				}
+			}
+			if d := ztest.Diff(have, tt.wantInternal); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestCheckGo(t *testing.T) {
+	errDB := errors.New("connection refused")
+	ctx := context.Background()
+
+	v := New()
+	v.Go(func(v *Validator) {
+		v.Check(ctx, "email", func(context.Context) (string, error) { return "must be unique", nil })
+	})
+	v.Go(func(v *Validator) {
+		v.Check(ctx, "username", func(context.Context) (string, error) { return "", errDB })
+	})
+	v.Wait()
+
+	if d := ztest.Diff(v.String(), "email: must be unique."); d != "" {
+		t.Error(d)
+	}
+	if !errors.Is(v.ErrorOrNil(), errDB) {
+		t.Errorf("wrong error: %v", v.ErrorOrNil())
+	}
+}
+
+func TestCheckSub(t *testing.T) {
+	errDB := errors.New("pq: connection refused to 10.0.0.5")
+	validate := func() error {
+		v := New()
+		v.Required("city", "")
+		v.Check(context.Background(), "zip", func(context.Context) (string, error) { return "", errDB })
+		return v.ErrorOrNil()
+	}
+
+	v := New()
+	v.Sub("address", "", validate())
+
+	if d := ztest.Diff(v.String(), "address.city: must be set."); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(fmt.Sprint(v.InternalError()), `zvalidate: check "zip": pq: connection refused to 10.0.0.5`); d != "" {
+		t.Error(d)
+	}
+	if !errors.Is(v.ErrorOrNil(), errDB) {
+		t.Errorf("wrong error: %v", v.ErrorOrNil())
+	}
+
+	// Only an internal error.
+	sub := New()
+	sub.Check(context.Background(), "zip", func(context.Context) (string, error) { return "", errDB })
+	v = New()
+	v.Sub("address", "", sub.ErrorOrNil())
+	if v.HasErrors() {
+		t.Errorf("has errors: %s", v)
+	}
+	if d := ztest.Diff(v.ErrorOrNil().Error(), "zvalidate: internal error"); d != "" {
+		t.Error(d)
+	}
+	if v.Code() != 500 {
+		t.Errorf("Code(): %d", v.Code())
+	}
+}
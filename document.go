@@ -0,0 +1,308 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Doc validates a decoded document, such as a JSON or YAML config file.
+//
+// Values are selected by path, in the same style as Path.String(), and the
+// errors are added with that path. For example:
+//
+//	var cfg map[string]any
+//	err := json.Unmarshal(data, &cfg)
+//
+//	d := zvalidate.NewDoc(cfg)
+//	d.Required("name", "listen.port")
+//	d.String("name")
+//	d.Range("listen.port", 1, 65535)
+//	d.Each("backends", func(d *zvalidate.Doc) {
+//	    d.Required("host")
+//	    d.Hostname("host")
+//	    d.Bool("tls")
+//	})
+//	if err := d.Validator.ErrorOrNil(); err != nil {
+//	    return err // "backends[1].host: must be set." etc.
+//	}
+//
+// All methods check the type of the value, adding an error if it's wrong. Values
+// that don't exist or are null are skipped; use Required() to check that they
+// exist.
+type Doc struct {
+	Data      map[string]any
+	Validator *Validator
+	prefix    Path
+}
+
+// NewDoc creates a new Doc with a new Validator.
+func NewDoc(data map[string]any) *Doc {
+	v := New()
+	return &Doc{Data: data, Validator: &v}
+}
+
+func (d *Doc) v() *Validator {
+	if d.Validator == nil {
+		v := New()
+		d.Validator = &v
+	}
+	return d.Validator
+}
+
+// Value gets the value at path, or nil if it doesn't exist.
+func (d *Doc) Value(path string) any {
+	_, val := d.get(path)
+	return val
+}
+
+// get the full path and value.
+func (d *Doc) get(path string) (Path, any) {
+	rel := ParsePath(path)
+	var val any = d.Data
+	for _, e := range rel {
+		switch n := val.(type) {
+		case map[string]any:
+			val = n[e.Name]
+		case []any:
+			i, err := strconv.Atoi(e.Name)
+			if err != nil || i < 0 || i >= len(n) {
+				val = nil
+			} else {
+				val = n[i]
+			}
+		default:
+			val = nil
+		}
+		if val == nil {
+			break
+		}
+	}
+	return d.prefix.join(rel...), val
+}
+
+// typeError adds an error for a value of the wrong type.
+func (d *Doc) typeError(p Path, typ string) {
+	v := d.v()
+	v.appendPath(p, CodeJSONType, fmt.Sprintf(v.msg.JSONType(), typ), map[string]any{"type": typ})
+}
+
+// Required validates that the paths exist and are not the zero value ("", 0,
+// false, or an empty object or array).
+func (d *Doc) Required(paths ...string) {
+	for _, path := range paths {
+		p, val := d.get(path)
+		d.v().at(p, func(v *Validator) { v.Required("", val) })
+	}
+}
+
+// Object validates that the value is an object.
+func (d *Doc) Object(path string) map[string]any {
+	p, val := d.get(path)
+	if val == nil {
+		return nil
+	}
+	m, ok := val.(map[string]any)
+	if !ok {
+		d.typeError(p, "object")
+	}
+	return m
+}
+
+// Array validates that the value is an array.
+func (d *Doc) Array(path string) []any {
+	p, val := d.get(path)
+	if val == nil {
+		return nil
+	}
+	a, ok := val.([]any)
+	if !ok {
+		d.typeError(p, "array")
+	}
+	return a
+}
+
+// Each validates that the value is an array of objects, and calls f for every
+// object with a Doc for that object.
+//
+// Errors are added with the index; e.g. "host" in "backends" is added as
+// "backends[1].host".
+func (d *Doc) Each(path string, f func(*Doc)) {
+	p, _ := d.get(path)
+	for i, e := range d.Array(path) {
+		ep := p.join(PathElem{Name: strconv.Itoa(i), Index: true})
+		m, ok := e.(map[string]any)
+		if !ok {
+			d.typeError(ep, "object")
+			continue
+		}
+		f(&Doc{Data: m, Validator: d.v(), prefix: ep})
+	}
+}
+
+// String validates that the value is a string.
+func (d *Doc) String(path string) string {
+	_, s, _ := d.str(path)
+	return s
+}
+
+func (d *Doc) str(path string) (Path, string, bool) {
+	p, val := d.get(path)
+	if val == nil {
+		return p, "", false
+	}
+	s, ok := val.(string)
+	if !ok {
+		d.typeError(p, "string")
+	}
+	return p, s, ok
+}
+
+// Bool validates that the value is a boolean.
+func (d *Doc) Bool(path string) bool {
+	p, val := d.get(path)
+	if val == nil {
+		return false
+	}
+	b, ok := val.(bool)
+	if !ok {
+		v := d.v()
+		v.appendPath(p, CodeBool, v.msg.Bool(), nil)
+	}
+	return b
+}
+
+// Number validates that the value is a number.
+func (d *Doc) Number(path string) float64 {
+	_, n, _ := d.num(path)
+	return n
+}
+
+func (d *Doc) num(path string) (Path, float64, bool) {
+	p, val := d.get(path)
+	if val == nil {
+		return p, 0, false
+	}
+
+	switch n := val.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		if err == nil {
+			return p, f, true
+		}
+	default:
+		rv := reflect.ValueOf(val)
+		switch {
+		case rv.CanFloat():
+			return p, rv.Float(), true
+		case rv.CanInt():
+			return p, float64(rv.Int()), true
+		case rv.CanUint():
+			return p, float64(rv.Uint()), true
+		}
+	}
+	d.typeError(p, "number")
+	return p, 0, false
+}
+
+// Integer validates that the value is a whole number.
+func (d *Doc) Integer(path string) int64 {
+	_, i, _ := d.int(path)
+	return i
+}
+
+func (d *Doc) int(path string) (Path, int64, bool) {
+	p, f, ok := d.num(path)
+	if !ok {
+		return p, 0, false
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		v := d.v()
+		v.appendPath(p, CodeInteger, v.msg.Integer(), nil)
+		return p, 0, false
+	}
+	return p, int64(f), true
+}
+
+// Range validates that the value is a whole number between min and max; a
+// maximum of 0 indicates there is no upper limit.
+func (d *Doc) Range(path string, min, max int64) int64 {
+	p, i, ok := d.int(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { v.Range("", i, min, max) })
+	}
+	return i
+}
+
+// Len validates the character length of a string.
+func (d *Doc) Len(path string, min, max int) string {
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { v.Len("", s, min, max) })
+	}
+	return s
+}
+
+// Include validates that the value is a string in the include list.
+func (d *Doc) Include(path string, include []string) string {
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { v.Include("", s, include) })
+	}
+	return s
+}
+
+// Hostname validates that the value is a hostname.
+func (d *Doc) Hostname(path string) string {
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { v.Hostname("", s) })
+	}
+	return s
+}
+
+// URL validates that the value is an URL.
+func (d *Doc) URL(path string) *url.URL {
+	var u *url.URL
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { u = v.URL("", s) })
+	}
+	return u
+}
+
+// Email validates that the value is an email address.
+func (d *Doc) Email(path string) mail.Address {
+	var a mail.Address
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { a = v.Email("", s) })
+	}
+	return a
+}
+
+// IP validates that the value is an IPv4 or IPv6 address.
+func (d *Doc) IP(path string) net.IP {
+	var ip net.IP
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { ip = v.IP("", s) })
+	}
+	return ip
+}
+
+// Date validates that the value is a date in the given layout.
+func (d *Doc) Date(path, layout string) time.Time {
+	var t time.Time
+	p, s, ok := d.str(path)
+	if ok {
+		d.v().at(p, func(v *Validator) { t = v.Date("", s, layout) })
+	}
+	return t
+}
@@ -0,0 +1,131 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestDoc(t *testing.T) {
+	var cfg map[string]any
+	err := json.Unmarshal([]byte(`{
+		"name":    "test",
+		"debug":   true,
+		"ratio":   0.5,
+		"listen":  {"port": 8080, "host": "localhost"},
+		"site":    "https://example.com",
+		"admin":   "admin@example.com",
+		"ip":      "::1",
+		"start":   "2020-01-01",
+		"mode":    "fast",
+		"tags":    ["a", "b"],
+		"backends": [
+			{"host": "a.example.com", "tls": true},
+			{"host": "b.example.com"}
+		]
+	}`), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDoc(cfg)
+	d.Required("name", "listen.port", "backends[0].host")
+	var hosts []string
+	d.Each("backends", func(d *Doc) {
+		d.Required("host")
+		hosts = append(hosts, d.Hostname("host"))
+		d.Bool("tls")
+	})
+	have := fmt.Sprintln(
+		d.String("name"), d.Bool("debug"), d.Number("ratio"), d.Range("listen.port", 1, 65535),
+		d.Len("listen.host", 1, 255), d.URL("site"), d.Email("admin").Address, d.IP("ip"),
+		d.Date("start", "2006-01-02").Year(), d.Include("mode", []string{"fast", "slow"}),
+		len(d.Object("listen")), len(d.Array("tags")), d.Value("tags[1]"), d.Value("backends[1].host"),
+		d.String("missing"), d.Integer("missing.x"), d.Value("tags[5]"), d.Value("tags.x"), hosts,
+	)
+	want := "test true 0.5 8080 localhost https://example.com admin@example.com ::1 2020 fast 2 2 b b.example.com  0 <nil> <nil> [a.example.com b.example.com]\n"
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+	if d.Validator.HasErrors() {
+		t.Error(d.Validator)
+	}
+}
+
+func TestDocErrors(t *testing.T) {
+	var cfg map[string]any
+	d := json.NewDecoder(strings.NewReader(`{
+		"name":    1,
+		"debug":   "yes",
+		"ratio":   "x",
+		"listen":  {"port": 80000, "host": ""},
+		"count":   1.5,
+		"site":    "nope",
+		"admin":   "nope",
+		"ip":      "nope",
+		"start":   "nope",
+		"mode":    "nope",
+		"tags":    {},
+		"opts":    [],
+		"backends": [
+			{"host": "a.example.com", "tls": "x"},
+			"x",
+			{"port": 1}
+		]
+	}`))
+	d.UseNumber()
+	err := d.Decode(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := NewDoc(cfg)
+	doc.Required("missing", "listen.host", "opts")
+	doc.Each("backends", func(d *Doc) {
+		d.Required("host")
+		d.Hostname("host")
+		d.Bool("tls")
+	})
+	doc.String("name")
+	doc.Bool("debug")
+	doc.Number("ratio")
+	doc.Range("listen.port", 1, 65535)
+	doc.Integer("count")
+	doc.URL("site")
+	doc.Email("admin")
+	doc.IP("ip")
+	doc.Date("start", "2006-01-02")
+	doc.Include("mode", []string{"fast", "slow"})
+	doc.Array("tags")
+	doc.Object("opts")
+
+	want := `
+admin: must be a valid email address.
+backends[0].tls: must be a boolean.
+backends[1]: must be of type object.
+backends[2].host: must be set.
+count: must be a whole number.
+debug: must be a boolean.
+ip: must be a valid IPv4 or IPv6 address.
+listen.host: must be set.
+listen.port: must be 65535 or lower.
+missing: must be set.
+mode: must be one of ‘fast, slow’.
+name: must be of type string.
+opts: must be set, must be of type object.
+ratio: must be of type number.
+site: must be a valid url.
+start: must be a date as ‘2006-01-02’.
+tags: must be of type array.`[1:]
+	if d := ztest.Diff(doc.Validator.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	have := mustJSON(t, doc.Validator.Tree()["backends"])
+	if d := ztest.Diff(have, `[{"tls":["must be a boolean"]},["must be of type object"],{"host":["must be set"]}]`); d != "" {
+		t.Error(d)
+	}
+}
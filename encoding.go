@@ -0,0 +1,144 @@
+package zvalidate
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON reads the errors and warnings as written by MarshalJSON().
+//
+// The keys are added in the order they appear in the JSON, and are parsed with
+// ParsePath() so that e.g. "addresses[0].city" has the same Path as it did
+// before encoding. Any previous errors
+// are removed, and the Messages are set to DefaultMessages if they weren't set
+// already.
+func (v *Validator) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Errors   json.RawMessage `json:"errors"`
+		Warnings json.RawMessage `json:"warnings"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("zvalidate.UnmarshalJSON: %w", err)
+	}
+
+	v.reset()
+	err = unmarshalErrors(raw.Errors, v)
+	if err != nil {
+		return err
+	}
+	if len(raw.Warnings) > 0 && !bytes.Equal(raw.Warnings, []byte("null")) {
+		err = unmarshalErrors(raw.Warnings, v.Warnings())
+	}
+	return err
+}
+
+// unmarshalErrors reads a {"key": ["msg", ..], ..} object, preserving the
+// order.
+func unmarshalErrors(data []byte, v *Validator) error {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != json.Delim('{') {
+		return fmt.Errorf("zvalidate.UnmarshalJSON: errors is not an object but %v", t)
+	}
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		var msgs []string
+		err = d.Decode(&msgs)
+		if err != nil {
+			return fmt.Errorf("zvalidate.UnmarshalJSON: key %q: %w", t, err)
+		}
+		key := t.(string)
+		p := ParsePath(key)
+		if p.String() != key {
+			p = keyPath(key)
+		}
+		for _, m := range msgs {
+			v.appendPath(p, "", m, nil)
+		}
+	}
+	_, err = d.Token()
+	return err
+}
+
+// wireValidator is what MarshalBinary() encodes.
+type wireValidator struct {
+	Errors   []FieldError
+	Warnings []FieldError
+	Ordered  bool
+}
+
+// MarshalBinary encodes the Validator with encoding/gob.
+//
+// Unlike the JSON representation this includes the Code, Params, and Path for
+// all errors. Errors from Unwrap() are not included.
+func (v Validator) MarshalBinary() ([]byte, error) {
+	w := wireValidator{Errors: v.allFieldErrors()}
+	if ww := v.warnings(); ww != nil {
+		w.Warnings = ww.allFieldErrors()
+	}
+	if v.state != nil {
+		w.Ordered = v.state.ordered
+	}
+
+	b := new(bytes.Buffer)
+	err := gob.NewEncoder(b).Encode(w)
+	if err != nil {
+		return nil, fmt.Errorf("zvalidate.MarshalBinary: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the Validator as encoded by MarshalBinary().
+//
+// Any previous errors are removed, and the Messages are set to DefaultMessages
+// if they weren't set already.
+func (v *Validator) UnmarshalBinary(data []byte) error {
+	var w wireValidator
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&w)
+	if err != nil {
+		return fmt.Errorf("zvalidate.UnmarshalBinary: %w", err)
+	}
+
+	v.reset()
+	v.Ordered(w.Ordered)
+	for _, e := range w.Errors {
+		v.appendPath(e.Path, e.Code, e.Message, e.Params)
+	}
+	for _, e := range w.Warnings {
+		v.Warnings().appendPath(e.Path, e.Code, e.Message, e.Params)
+	}
+	return nil
+}
+
+// allFieldErrors gets all FieldErrors in the order they were added.
+func (v *Validator) allFieldErrors() []FieldError {
+	keys := v.Keys()
+	errs := make([]FieldError, 0, len(keys))
+	for _, k := range keys {
+		errs = append(errs, v.fieldErrors(k)...)
+	}
+	return errs
+}
+
+// reset the Validator to an empty one, keeping the Messages and Templates.
+func (v *Validator) reset() {
+	msg, tpl := v.msg, v.tpl
+	if msg.Required == nil {
+		msg = DefaultMessages
+	}
+	*v = New()
+	v.msg, v.tpl = msg, tpl
+}
@@ -0,0 +1,160 @@
+package zvalidate
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Required("zip", "")
+	v.Append("name", "oh noes")
+	v.Append("name", "more")
+	v.Warn("url", "no https")
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var have Validator
+	err = json.Unmarshal(j, &have)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make sure we don't panic on nil Messages.
+	have.Required("email", "")
+
+	want := "zip: must be set.\nname: oh noes, more.\nemail: must be set.\nurl: warning: no https.\n"
+	have.Ordered(true)
+	if d := ztest.Diff(have.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	{ // Merge back.
+		m := New()
+		m.Merge(have)
+		want := "email: must be set.\nname: oh noes, more.\nzip: must be set.\nurl: warning: no https.\n"
+		if d := ztest.Diff(m.String(), want); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestUnmarshalJSONPath(t *testing.T) {
+	addr := New()
+	addr.Required("city", "")
+	v := New()
+	v.Sub("addresses", "0", addr)
+	v.Append("global.", "odd key")
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have Validator
+	err = json.Unmarshal(j, &have)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := ztest.Diff(have.String(), v.String()); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(mustJSON(t, have.Tree()), `{"addresses":[{"city":["must be set"]}],"global.":["odd key"]}`); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(have.JSONAPIErrors()[0].Source.Pointer, "/data/attributes/addresses/0/city"); d != "" {
+		t.Error(d)
+	}
+
+	m := New()
+	m.Sub("user", "", have)
+	if d := ztest.Diff(m.FieldErrors()[0].Path.JSONPointer(), "/user/addresses/0/city"); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"errors": []}`, `zvalidate.UnmarshalJSON: errors is not an object but [`},
+		{`{"errors": {"a": "x"}}`, `zvalidate.UnmarshalJSON: key "a": json: cannot unmarshal`},
+		{`[]`, `zvalidate.UnmarshalJSON: json: cannot unmarshal array`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			var v Validator
+			err := json.Unmarshal([]byte(tt.in), &v)
+			if err == nil {
+				t.Fatal("err is nil")
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("\nhave: %s\nwant: %s", err, tt.want)
+			}
+		})
+	}
+
+	{ // null
+		var v Validator
+		err := json.Unmarshal([]byte(`{"errors": null}`), &v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.HasErrors() {
+			t.Error(v.Errors)
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Len("name", "x", 2, 5)
+	v.Include("color", "x", []string{"red", "blue"})
+	v.Range("age", 200, 0, 150)
+	v.Warnings().Date("dob", "x", "2006-01-02")
+
+	addr := New()
+	addr.Required("city", "")
+	v.Sub("addresses", "0", addr)
+
+	b := new(bytes.Buffer)
+	err := gob.NewEncoder(b).Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var have Validator
+	err = gob.NewDecoder(b).Decode(&have)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := ztest.Diff(have.String(), v.String()); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(mustJSON(t, have.FieldErrors()), mustJSON(t, v.FieldErrors())); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(mustJSON(t, have.Warnings().FieldErrors()), mustJSON(t, v.Warnings().FieldErrors())); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(have.FieldErrors()[3].Path.JSONPointer(), "/addresses/0/city"); d != "" {
+		t.Error(d)
+	}
+
+	have.Required("email", "")
+	if !have.Failed("email") {
+		t.Error("no error for email")
+	}
+}
@@ -0,0 +1,174 @@
+package zvalidate
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Env loads and validates environment variables.
+//
+// The errors are added to Validator with the full variable name as the key,
+// so that all misconfigurations can be reported at once. Variables that are
+// unset or empty use the default. For example:
+//
+//	e := zvalidate.NewEnv("MYAPP_")
+//	e.Required("DB")
+//	var (
+//	    db      = e.String("DB", "")
+//	    workers = e.Range("WORKERS", 4, 1, 64)
+//	    site    = e.URL("SITE", "http://localhost:8080")
+//	    debug   = e.Boolean("DEBUG", false)
+//	)
+//	if err := e.Validate(); err != nil {
+//	    fmt.Fprintln(os.Stderr, err) // "MYAPP_DB: must be set." etc.
+//	    os.Exit(1)
+//	}
+type Env struct {
+	// Prefix for all variable names.
+	Prefix string
+
+	// Lookup gets a variable; this is os.LookupEnv by default, and can be
+	// replaced in tests.
+	Lookup func(string) (string, bool)
+
+	Validator *Validator
+}
+
+// NewEnv creates a new Env with the given prefix.
+func NewEnv(prefix string) *Env {
+	v := New()
+	return &Env{Prefix: prefix, Lookup: os.LookupEnv, Validator: &v}
+}
+
+// get the full name and value for a variable.
+func (e *Env) get(name string) (string, string) {
+	if e.Validator == nil {
+		v := New()
+		e.Validator = &v
+	}
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	key := e.Prefix + name
+	val, _ := lookup(key)
+	return key, val
+}
+
+// Validate returns all errors, or nil if there are none.
+func (e *Env) Validate() error {
+	if e.Validator == nil {
+		return nil
+	}
+	return e.Validator.ErrorOrNil()
+}
+
+// Required validates that the variables are set and not empty.
+func (e *Env) Required(names ...string) {
+	for _, n := range names {
+		key, val := e.get(n)
+		e.Validator.Required(key, val)
+	}
+}
+
+// String gets a variable as a string.
+func (e *Env) String(name, def string) string {
+	_, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	return val
+}
+
+// Len gets a variable as a string, validating the character length.
+func (e *Env) Len(name, def string, min, max int) string {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	e.Validator.Len(key, val, min, max)
+	return val
+}
+
+// Include gets a variable that must be in the include list.
+func (e *Env) Include(name, def string, include []string) string {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	r, _ := e.Validator.Include(key, val, include).(string)
+	return r
+}
+
+// Integer gets a variable as an integer.
+func (e *Env) Integer(name string, def int64) int64 {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	return e.Validator.Integer(key, val)
+}
+
+// Range gets a variable as an integer with a minimum and maximum value; a
+// maximum of 0 indicates there is no upper limit.
+func (e *Env) Range(name string, def, min, max int64) int64 {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	n := len(e.Validator.Errors[key])
+	i := e.Validator.Integer(key, val)
+	if len(e.Validator.Errors[key]) == n {
+		e.Validator.Range(key, i, min, max)
+	}
+	return i
+}
+
+// Boolean gets a variable as a boolean.
+func (e *Env) Boolean(name string, def bool) bool {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	return e.Validator.Boolean(key, val)
+}
+
+// Date gets a variable as a date in the given layout.
+func (e *Env) Date(name, layout string, def time.Time) time.Time {
+	key, val := e.get(name)
+	if val == "" {
+		return def
+	}
+	return e.Validator.Date(key, val, layout)
+}
+
+// URL gets a variable as an URL; local URLs such as "http://localhost" are
+// allowed.
+func (e *Env) URL(name, def string) *url.URL {
+	key, val := e.get(name)
+	if val == "" {
+		val = def
+	}
+	return e.Validator.URLLocal(key, val)
+}
+
+// Email gets a variable as an email address.
+func (e *Env) Email(name, def string) mail.Address {
+	key, val := e.get(name)
+	if val == "" {
+		val = def
+	}
+	return e.Validator.Email(key, val)
+}
+
+// IP gets a variable as an IPv4 or IPv6 address.
+func (e *Env) IP(name, def string) net.IP {
+	key, val := e.get(name)
+	if val == "" {
+		val = def
+	}
+	return e.Validator.IP(key, val)
+}
@@ -0,0 +1,85 @@
+package zvalidate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestEnv(t *testing.T) {
+	env := map[string]string{
+		"X_NAME":    "test",
+		"X_PORT":    "8080",
+		"X_DEBUG":   "true",
+		"X_SITE":    "http://localhost:8080/x",
+		"X_MODE":    "slow",
+		"X_START":   "2020-01-01",
+		"X_EMPTY":   "",
+		"X_EMAIL":   "a@example.com",
+		"X_IP":      "::1",
+		"OTHER_ENV": "x",
+	}
+	e := NewEnv("X_")
+	e.Lookup = func(k string) (string, bool) { v, ok := env[k]; return v, ok }
+
+	e.Required("NAME", "PORT")
+	have := fmt.Sprintln(
+		e.String("NAME", "def"), e.String("EMPTY", "def"), e.String("UNSET", "def"),
+		e.Range("PORT", 80, 1, 65535), e.Integer("UNSET", 42),
+		e.Boolean("DEBUG", false), e.Boolean("UNSET", true),
+		e.URL("SITE", ""), e.URL("UNSET", "http://example.com"), e.URL("EMPTY", "") == nil,
+		e.Include("MODE", "fast", []string{"fast", "slow"}),
+		e.Date("START", time.DateOnly, time.Time{}).Year(),
+		e.Email("EMAIL", "").Address, e.IP("IP", "127.0.0.1"), e.IP("UNSET", "127.0.0.1"),
+		e.Len("NAME", "", 0, 10),
+	)
+	want := `test def def 8080 42 true true http://localhost:8080/x http://example.com true slow 2020 a@example.com ::1 127.0.0.1 test
+`
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+	if err := e.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEnvErrors(t *testing.T) {
+	env := map[string]string{
+		"PORT":  "x",
+		"N":     "100",
+		"DEBUG": "maybe",
+		"SITE":  "http://",
+		"MODE":  "medium",
+		"START": "yesterday",
+		"EMAIL": "x",
+		"NAME":  "too long",
+	}
+	e := NewEnv("")
+	e.Lookup = func(k string) (string, bool) { v, ok := env[k]; return v, ok }
+
+	e.Required("DB", "PORT")
+	e.Integer("PORT", 0)
+	e.Range("N", 5, 1, 10)
+	e.Boolean("DEBUG", false)
+	e.URL("SITE", "")
+	e.Include("MODE", "", []string{"fast", "slow"})
+	e.Date("START", time.DateOnly, time.Time{})
+	e.Email("EMAIL", "")
+	e.Len("NAME", "", 0, 4)
+
+	want := `
+DB: must be set.
+DEBUG: must be a boolean.
+EMAIL: must be a valid email address.
+MODE: must be one of ‘fast, slow’.
+N: must be 10 or lower.
+NAME: must be shorter than 4 characters.
+PORT: must be a whole number.
+SITE: must be a valid url.
+START: must be a date as ‘2006-01-02’.`[1:]
+	if d := ztest.Diff(e.Validate().Error(), want); d != "" {
+		t.Error(d)
+	}
+}
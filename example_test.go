@@ -27,11 +27,12 @@ func Example() {
 func ExampleTemplateError() {
 	funcs := template.FuncMap{
 		"validate":   zvalidate.TemplateError,
+		"aria":       zvalidate.TemplateAria,
 		"has_errors": zvalidate.TemplateHasErrors,
 	}
 
 	t := template.Must(template.New("").Funcs(funcs).Parse(`
-<input name="xxx">
+<input name="xxx" {{aria "xxx" .Validate}}>
 {{validate "xxx" .Validate}}
 
 {{if has_errors .Validate}}Hidden: {{.Validate.HTML}}{{end}}
@@ -46,8 +47,8 @@ func ExampleTemplateError() {
 	})
 
 	// Output:
-	// <input name="xxx">
-	// <span class="err">Error: oh noes</span>
+	// <input name="xxx" aria-invalid="true" aria-describedby="err-xxx">
+	// <span class="err" id="err-xxx">Error: oh noes</span>
 	//
 	// Hidden: <ul class='zvalidate'>
 	// <li><strong>hidden</strong>: sneaky.</li>
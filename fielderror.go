@@ -0,0 +1,133 @@
+package zvalidate
+
+// Codes for the built-in validators.
+//
+// These are stable and can be relied upon by API clients, unlike the messages
+// which may be translated or changed with Messages().
+const (
+	CodeRequired           = "required"
+	CodeDomain             = "domain"
+	CodeHostname           = "hostname"
+	CodeURL                = "url"
+	CodeEmail              = "email"
+	CodeIPv4               = "ipv4"
+	CodeIP                 = "ip"
+	CodeHexColor           = "hex_color"
+	CodeLenTooShort        = "len_too_short"
+	CodeLenTooLong         = "len_too_long"
+	CodeExclude            = "exclude"
+	CodeInclude            = "include"
+	CodeInteger            = "integer"
+	CodeHex                = "hex"
+	CodeOctal              = "octal"
+	CodeBool               = "bool"
+	CodeDate               = "date"
+	CodePhone              = "phone"
+	CodePhoneInternational = "phone_international"
+	CodeRangeTooLow        = "range_too_low"
+	CodeRangeTooHigh       = "range_too_high"
+	CodeUTF8               = "utf8"
+	CodeContains           = "contains"
+	CodeUnexpected         = "unexpected"
+	CodeDuplicate          = "duplicate"
+	CodeJSONType           = "json_type"
+	CodeJSONSyntax         = "json_syntax"
+	CodeMultipleOf         = "multiple_of"
+	CodeFloat              = "float"
+	CodeDecimal            = "decimal"
+	CodeDecimalInt         = "decimal_int_digits"
+	CodeDecimalFrac        = "decimal_frac_digits"
+)
+
+// FieldError is a single validation error.
+//
+// Code is one of the Code* constants for the built-in validators, or empty for
+// errors added with Append() or Appendf(). Params are the parameters that
+// produced the error, such as "min" and "max" for Len(), and Message is the
+// rendered message as it appears in Validator.Errors.
+//
+// Key is the Path rendered with Path.String().
+type FieldError struct {
+	Key     string         `json:"key"`
+	Path    Path           `json:"-"`
+	Code    string         `json:"code,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
+	Message string         `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if e.Key == "" {
+		return e.Message
+	}
+	return e.Key + ": " + e.Message
+}
+
+// FieldErrors gets all errors as a FieldError, ordered by key or in the order
+// they were added if Ordered() is set.
+//
+// Errors added to the Errors map directly (instead of with Append() or one of
+// the validators) will have no Code or Params, and the key as the only element
+// in the Path.
+func (v *Validator) FieldErrors() []FieldError {
+	if !v.HasErrors() {
+		return nil
+	}
+
+	keys := v.keys()
+	errs := make([]FieldError, 0, len(keys))
+	for _, k := range keys {
+		errs = append(errs, v.fieldErrors(k)...)
+	}
+	return errs
+}
+
+// fieldErrors gets the FieldErrors for a single key, falling back to just the
+// messages if the details got out of sync with Errors.
+func (v *Validator) fieldErrors(key string) []FieldError {
+	msgs := v.Errors[key]
+	if d := v.details[key]; len(d) == len(msgs) {
+		ok := true
+		for i := range d {
+			if d[i].Message != msgs[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return d
+		}
+	}
+
+	errs := make([]FieldError, 0, len(msgs))
+	for _, m := range msgs {
+		errs = append(errs, FieldError{Key: key, Path: keyPath(key), Message: m})
+	}
+	return errs
+}
+
+// appendError appends a new error with the given code and parameters.
+func (v *Validator) appendError(key, code, msg string, params map[string]any) {
+	v.appendPath(keyPath(key), code, msg, params)
+}
+
+// appendPath appends a new error for a path with the given code and
+// parameters.
+func (v *Validator) appendPath(p Path, code, msg string, params map[string]any) {
+	key := p.String()
+	if v.bailed(key) {
+		return
+	}
+
+	if v.Errors == nil {
+		v.Errors = make(map[string][]string)
+	}
+	if v.details == nil {
+		v.details = make(map[string][]FieldError)
+	}
+	if _, ok := v.Errors[key]; !ok {
+		st := v.st()
+		st.keys = append(st.keys, key)
+	}
+	v.Errors[key] = append(v.Errors[key], msg)
+	v.details[key] = append(v.details[key], FieldError{Key: key, Path: p, Code: code, Params: params, Message: msg})
+}
@@ -0,0 +1,111 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestFieldErrors(t *testing.T) {
+	tests := []struct {
+		val  func(*Validator)
+		want string
+	}{
+		{func(v *Validator) {}, `null`},
+		{
+			func(v *Validator) { v.Required("k", "") },
+			`[{"key":"k","code":"required","message":"must be set"}]`,
+		},
+		{
+			func(v *Validator) { v.Len("k", "asd", 4, 0) },
+			`[{"key":"k","code":"len_too_short","params":{"max":0,"min":4},"message":"must be longer than 4 characters"}]`,
+		},
+		{
+			func(v *Validator) { v.Len("k", "asd", 0, 2, "custom %d") },
+			`[{"key":"k","code":"len_too_long","params":{"max":2,"min":0},"message":"custom 2"}]`,
+		},
+		{
+			func(v *Validator) { v.Range("k", 1, 2, 0) },
+			`[{"key":"k","code":"range_too_low","params":{"max":0,"min":2},"message":"must be 2 or higher"}]`,
+		},
+		{
+			func(v *Validator) { v.Include("k", "x", []string{"a", "b"}) },
+			`[{"key":"k","code":"include","params":{"include":["a","b"]},"message":"must be one of ‘a, b’"}]`,
+		},
+		{
+			func(v *Validator) { v.Date("k", "x", time.DateOnly) },
+			`[{"key":"k","code":"date","params":{"layout":"2006-01-02"},"message":"must be a date as ‘2006-01-02’"}]`,
+		},
+		{
+			func(v *Validator) {
+				v.Email("b", "x")
+				v.Append("a", "oh noes")
+			},
+			`[{"key":"a","message":"oh noes"},{"key":"b","code":"email","message":"must be a valid email address"}]`,
+		},
+		{
+			func(v *Validator) {
+				s := New()
+				s.Integer("i", "x")
+				v.Sub("sub", "1", s)
+				v.Sub("err", "", errors.New("oh noes"))
+			},
+			`[{"key":"err","message":"oh noes"},{"key":"sub[1].i","code":"integer","message":"must be a whole number"}]`,
+		},
+		{
+			func(v *Validator) {
+				o := New()
+				o.Boolean("b", "x")
+				v.Merge(o)
+			},
+			`[{"key":"b","code":"bool","message":"must be a boolean"}]`,
+		},
+		{ // Modified directly.
+			func(v *Validator) {
+				v.HexColor("c", "x")
+				v.Errors["c"] = []string{"other"}
+				v.Errors["d"] = []string{"direct"}
+			},
+			`[{"key":"c","message":"other"},{"key":"d","message":"direct"}]`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			tt.val(&v)
+
+			have := mustJSON(t, v.FieldErrors())
+			if d := ztest.Diff(have, tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestFieldErrorsPop(t *testing.T) {
+	v := New()
+	v.Required("a", "")
+	v.Required("b", "")
+	v.Pop("a")
+	v.Append("a", "x")
+
+	have := mustJSON(t, v.FieldErrors())
+	want := `[{"key":"a","message":"x"},{"key":"b","code":"required","message":"must be set"}]`
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(j)
+}
@@ -0,0 +1,167 @@
+package zvalidate
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Flags adds flags to a flag.FlagSet that are validated with the validators.
+//
+// Invalid values are rejected by FlagSet.Parse() with the same message as the
+// validator, and are added to Validator. Use Validate() after parsing to check
+// the required flags. For example:
+//
+//	f := zvalidate.NewFlags(nil)
+//	email := f.Email("email", "", "Email address to send the report to")
+//	url := f.URL("url", "", "URL to check")
+//	f.Required("email", "url")
+//
+//	flag.Parse()
+//	if err := f.Validate(); err != nil {
+//	    fmt.Fprintln(os.Stderr, err)
+//	    os.Exit(2)
+//	}
+type Flags struct {
+	FlagSet   *flag.FlagSet
+	Validator *Validator
+	required  []string
+}
+
+// NewFlags creates a new Flags for the FlagSet, or flag.CommandLine if fs is
+// nil.
+func NewFlags(fs *flag.FlagSet) *Flags {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	v := New()
+	return &Flags{FlagSet: fs, Validator: &v}
+}
+
+// Required marks the flags as required.
+//
+// It will panic if a flag doesn't exist.
+func (f *Flags) Required(names ...string) {
+	for _, n := range names {
+		if f.FlagSet.Lookup(n) == nil {
+			panic(fmt.Sprintf("zvalidate.Flags.Required: no flag %q", n))
+		}
+	}
+	f.required = append(f.required, names...)
+}
+
+// Validate adds an error for every required flag that wasn't set, and returns
+// all errors.
+//
+// This should be called after FlagSet.Parse().
+func (f *Flags) Validate() error {
+	set := make(map[string]struct{})
+	f.FlagSet.Visit(func(fl *flag.Flag) { set[fl.Name] = struct{}{} })
+	for _, n := range f.required {
+		if _, ok := set[n]; !ok {
+			f.Validator.appendError(n, CodeRequired, f.Validator.msg.Required(), nil)
+		}
+	}
+	return f.Validator.ErrorOrNil()
+}
+
+// Email defines a flag for an email address.
+func (f *Flags) Email(name, value, usage string) *mail.Address {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) mail.Address { return v.Email("", s) })
+}
+
+// URL defines a flag for an URL.
+func (f *Flags) URL(name, value, usage string) *url.URL {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) url.URL {
+		if u := v.URL("", s); u != nil {
+			return *u
+		}
+		return url.URL{}
+	})
+}
+
+// IP defines a flag for an IPv4 or IPv6 address.
+func (f *Flags) IP(name, value, usage string) *net.IP {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) net.IP { return v.IP("", s) })
+}
+
+// Date defines a flag for a date in the given layout.
+func (f *Flags) Date(name, value, layout, usage string) *time.Time {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) time.Time { return v.Date("", s, layout) })
+}
+
+// HexColor defines a flag for a colour as a hex triplet.
+func (f *Flags) HexColor(name, value, usage string) *color.RGBA {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) color.RGBA {
+		if s == "" {
+			return color.RGBA{}
+		}
+		r, g, b := v.HexColor("", s)
+		return color.RGBA{R: r, G: g, B: b, A: 255}
+	})
+}
+
+// Range defines a flag for an integer with a minimum and maximum value; a
+// maximum of 0 indicates there is no upper limit.
+func (f *Flags) Range(name, value string, min, max int64, usage string) *int64 {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) int64 {
+		i := v.Integer("", s)
+		if s != "" && !v.HasErrors() {
+			v.Range("", i, min, max)
+		}
+		return i
+	})
+}
+
+// Include defines a flag for a value from the include list.
+func (f *Flags) Include(name, value string, include []string, usage string) *string {
+	return defineFlag(f, name, value, usage, func(v *Validator, s string) string {
+		r, _ := v.Include("", s, include).(string)
+		return r
+	})
+}
+
+type flagValue[T any] struct {
+	f     *Flags
+	name  string
+	str   string
+	val   *T
+	parse func(*Validator, string) T
+}
+
+// defineFlag defines a new flag; it will panic if the default value is
+// invalid.
+func defineFlag[T any](f *Flags, name, value, usage string, parse func(*Validator, string) T) *T {
+	fv := &flagValue[T]{f: f, name: name, val: new(T), parse: parse}
+	if value != "" {
+		sub := New()
+		sub.msg = f.Validator.msg
+		*fv.val = parse(&sub, value)
+		if sub.HasErrors() {
+			panic(fmt.Sprintf("zvalidate.Flags: invalid default %q for flag -%s: %s", value, name, sub.Errors[""][0]))
+		}
+		fv.str = value
+	}
+	f.FlagSet.Var(fv, name, usage)
+	return fv.val
+}
+
+func (fv *flagValue[T]) String() string { return fv.str }
+
+func (fv *flagValue[T]) Set(s string) error {
+	sub := New()
+	sub.msg = fv.f.Validator.msg
+	val := fv.parse(&sub, s)
+	if sub.HasErrors() {
+		fv.f.Validator.SubPath(keyPath(fv.name), &sub)
+		return errors.New(strings.Join(sub.Errors[""], ", "))
+	}
+	fv.str, *fv.val = s, val
+	return nil
+}
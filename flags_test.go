@@ -0,0 +1,123 @@
+package zvalidate
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestFlags(t *testing.T) {
+	newFlags := func() (*Flags, map[string]any) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		f := NewFlags(fs)
+		return f, map[string]any{
+			"email": f.Email("email", "", ""),
+			"url":   f.URL("url", "", ""),
+			"ip":    f.IP("ip", "", ""),
+			"date":  f.Date("date", "2020-01-01", time.DateOnly, ""),
+			"color": f.HexColor("color", "", ""),
+			"n":     f.Range("n", "5", 1, 10, ""),
+			"mode":  f.Include("mode", "", []string{"fast", "slow"}, ""),
+		}
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+		want    map[string]string
+	}{
+		{[]string{}, "", map[string]string{
+			"date": "2020-01-01 00:00:00 +0000 UTC", "n": "5", "email": "{ }", "url": "", "color": "{0 0 0 0}",
+		}},
+		{[]string{"-email", "a@example.com", "-url", "http://example.com", "-ip", "::1",
+			"-date", "2021-02-03", "-color", "#ff0000", "-n", "10", "-mode", "slow"}, "", map[string]string{
+			"email": "{ a@example.com}", "url": "http://example.com", "ip": "::1", "date": "2021-02-03 00:00:00 +0000 UTC",
+			"color": "{255 0 0 255}", "n": "10", "mode": "slow",
+		}},
+		{[]string{"-email", "x"}, `invalid value "x" for flag -email: must be a valid email address`, nil},
+		{[]string{"-ip", "x"}, `invalid value "x" for flag -ip: must be a valid IPv4 or IPv6 address`, nil},
+		{[]string{"-date", "x"}, `invalid value "x" for flag -date: must be a date as ‘2006-01-02’`, nil},
+		{[]string{"-color", "x"}, `invalid value "x" for flag -color: must be a valid color code`, nil},
+		{[]string{"-n", "x"}, `invalid value "x" for flag -n: must be a whole number`, nil},
+		{[]string{"-n", "11"}, `invalid value "11" for flag -n: must be 10 or lower`, nil},
+		{[]string{"-mode", "x"}, `invalid value "x" for flag -mode: must be one of ‘fast, slow’`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			f, vals := newFlags()
+			err := f.FlagSet.Parse(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("wrong error\nhave: %v\nwant: %v", err, tt.wantErr)
+				}
+				if !f.Validator.HasErrors() {
+					t.Error("no errors in Validator")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for k, want := range tt.want {
+				have := fmt.Sprint(reflect.ValueOf(vals[k]).Elem().Interface())
+				if k == "url" {
+					have = vals[k].(*url.URL).String()
+				}
+				if d := ztest.Diff(have, want); d != "" {
+					t.Errorf("%s: %s", k, d)
+				}
+			}
+		})
+	}
+}
+
+func TestFlagsRequired(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := NewFlags(fs)
+	f.Email("email", "", "")
+	f.URL("url", "", "")
+	f.IP("ip", "", "")
+	f.Required("email", "url", "ip")
+	fs.SetOutput(io.Discard)
+	fs.PrintDefaults()
+
+	err := fs.Parse([]string{"-ip", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.Validate()
+	want := "email: must be set.\nurl: must be set."
+	if d := ztest.Diff(err.Error(), want); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestFlagsPanic(t *testing.T) {
+	f := NewFlags(flag.NewFlagSet("test", flag.ContinueOnError))
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("no panic for default")
+			}
+		}()
+		f.Email("email", "x", "")
+	}()
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("no panic for Required")
+			}
+		}()
+		f.Required("nope")
+	}()
+}
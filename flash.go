@@ -0,0 +1,247 @@
+package zvalidate
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Errors returned by Flash.Decode().
+var (
+	ErrFlashInvalid  = errors.New("zvalidate: invalid or tampered flash value")
+	ErrFlashTooLarge = errors.New("zvalidate: flash value is too large")
+	ErrFlashExpired  = errors.New("zvalidate: flash value has expired")
+)
+
+// Flash encodes a Validator and the submitted form values in a string that can
+// be stored in a cookie, for the POST-redirect-GET pattern.
+//
+// The value is signed with HMAC-SHA256, and optionally encrypted with
+// AES-GCM. Without EncryptKey the value is only signed, and anyone with access
+// to the cookie can read the form values. Fields that look like passwords or
+// card numbers are never stored; see Exclude.
+//
+// For example:
+//
+//	var flash = zvalidate.Flash{Key: hmacKey}
+//
+//	func submit(w http.ResponseWriter, r *http.Request) {
+//	    v := validateForm(r.Form)
+//	    if v.HasErrors() {
+//	        val, err := flash.Encode(&v, r.Form)
+//	        // Handle err
+//	        http.SetCookie(w, &http.Cookie{Name: "flash", Value: val, Path: "/", HttpOnly: true})
+//	        http.Redirect(w, r, "/form", http.StatusSeeOther)
+//	        return
+//	    }
+//	}
+//
+//	func form(w http.ResponseWriter, r *http.Request) {
+//	    var (
+//	        v    *zvalidate.Validator
+//	        form url.Values
+//	    )
+//	    if c, err := r.Cookie("flash"); err == nil {
+//	        v, form, err = flash.Decode(c.Value)
+//	        // Handle err
+//	        http.SetCookie(w, &http.Cookie{Name: "flash", Path: "/", MaxAge: -1})
+//	    }
+//	    // Render template with v and form.
+//	}
+type Flash struct {
+	// Key to sign the value with; this is required. It should be at least 32
+	// random bytes.
+	Key []byte
+
+	// Key to encrypt the value with; this must be 16, 24, or 32 bytes to select
+	// AES-128, AES-192, or AES-256. The value isn't encrypted if this is nil.
+	EncryptKey []byte
+
+	// Maximum size of the encoded value; defaults to 4000 bytes, which is about
+	// the maximum for a cookie.
+	MaxSize int
+
+	// Maximum age of the value; it never expires if this is 0.
+	MaxAge time.Duration
+
+	// Form fields to exclude from the stored form values; fields are excluded
+	// if any of these appear anywhere in the name, ignoring case. The
+	// DefaultFlashExclude list is used if this is nil; use an empty slice to
+	// store all fields.
+	Exclude []string
+}
+
+// DefaultFlashExclude is the default for Flash.Exclude.
+var DefaultFlashExclude = []string{"password", "passwd", "secret", "token", "card", "cvc", "cvv"}
+
+type wireFlash struct {
+	Validator []byte
+	Form      url.Values
+	Created   int64
+}
+
+// exclude removes all excluded fields from form.
+func (f Flash) exclude(form url.Values) url.Values {
+	exclude := f.Exclude
+	if exclude == nil {
+		exclude = DefaultFlashExclude
+	}
+	if len(exclude) == 0 || len(form) == 0 {
+		return form
+	}
+
+	n := make(url.Values, len(form))
+outer:
+	for k, v := range form {
+		lk := strings.ToLower(k)
+		for _, e := range exclude {
+			if strings.Contains(lk, strings.ToLower(e)) {
+				continue outer
+			}
+		}
+		n[k] = v
+	}
+	return n
+}
+
+func (f Flash) maxSize() int {
+	if f.MaxSize > 0 {
+		return f.MaxSize
+	}
+	return 4000
+}
+
+// Encode the Validator and form values.
+//
+// Fields matching Exclude are removed from form. This returns ErrFlashTooLarge
+// if the encoded value is larger than MaxSize.
+func (f Flash) Encode(v *Validator, form url.Values) (string, error) {
+	if len(f.Key) == 0 {
+		return "", errors.New("zvalidate.Flash: Key is empty")
+	}
+
+	vb, err := v.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	b := new(bytes.Buffer)
+	z, err := flate.NewWriter(b, flate.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("zvalidate.Flash: %w", err)
+	}
+	err = gob.NewEncoder(z).Encode(wireFlash{Validator: vb, Form: f.exclude(form), Created: time.Now().Unix()})
+	if err != nil {
+		return "", fmt.Errorf("zvalidate.Flash: %w", err)
+	}
+	err = z.Close()
+	if err != nil {
+		return "", fmt.Errorf("zvalidate.Flash: %w", err)
+	}
+
+	data := b.Bytes()
+	if f.EncryptKey != nil {
+		gcm, err := f.gcm()
+		if err != nil {
+			return "", err
+		}
+		nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(data)+gcm.Overhead())
+		_, err = rand.Read(nonce)
+		if err != nil {
+			return "", fmt.Errorf("zvalidate.Flash: %w", err)
+		}
+		data = gcm.Seal(nonce, nonce, data, nil)
+	}
+
+	enc := base64.RawURLEncoding.EncodeToString(f.sign(data))
+	if len(enc) > f.maxSize() {
+		return "", ErrFlashTooLarge
+	}
+	return enc, nil
+}
+
+// Decode a value created with Encode().
+//
+// This returns ErrFlashInvalid if the value was tampered with or can't be
+// decoded, ErrFlashTooLarge if it's larger than MaxSize, or ErrFlashExpired if
+// it's older than MaxAge.
+func (f Flash) Decode(value string) (*Validator, url.Values, error) {
+	if len(f.Key) == 0 {
+		return nil, nil, errors.New("zvalidate.Flash: Key is empty")
+	}
+	if len(value) > f.maxSize() {
+		return nil, nil, ErrFlashTooLarge
+	}
+
+	signed, err := base64.RawURLEncoding.Strict().DecodeString(value)
+	if err != nil || len(signed) < sha256.Size {
+		return nil, nil, ErrFlashInvalid
+	}
+	data, sig := signed[:len(signed)-sha256.Size], signed[len(signed)-sha256.Size:]
+	if !hmac.Equal(sig, f.sign(data)[len(data):]) {
+		return nil, nil, ErrFlashInvalid
+	}
+
+	if f.EncryptKey != nil {
+		gcm, err := f.gcm()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) < gcm.NonceSize() {
+			return nil, nil, ErrFlashInvalid
+		}
+		data, err = gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+		if err != nil {
+			return nil, nil, ErrFlashInvalid
+		}
+	}
+
+	// Already verified the signature, but limit the size anyway in case the
+	// key leaked.
+	var w wireFlash
+	err = gob.NewDecoder(io.LimitReader(flate.NewReader(bytes.NewReader(data)), int64(f.maxSize())*64)).Decode(&w)
+	if err != nil {
+		return nil, nil, ErrFlashInvalid
+	}
+	if f.MaxAge > 0 && time.Since(time.Unix(w.Created, 0)) > f.MaxAge {
+		return nil, nil, ErrFlashExpired
+	}
+
+	v := New()
+	err = v.UnmarshalBinary(w.Validator)
+	if err != nil {
+		return nil, nil, ErrFlashInvalid
+	}
+	return &v, w.Form, nil
+}
+
+// sign data, returning the data with the signature appended.
+func (f Flash) sign(data []byte) []byte {
+	h := hmac.New(sha256.New, f.Key)
+	h.Write(data)
+	return h.Sum(data[:len(data):len(data)])
+}
+
+func (f Flash) gcm() (cipher.AEAD, error) {
+	c, err := aes.NewCipher(f.EncryptKey)
+	if err != nil {
+		return nil, fmt.Errorf("zvalidate.Flash: %w", err)
+	}
+	gcm, err := cipher.NewGCM(c)
+	if err != nil {
+		return nil, fmt.Errorf("zvalidate.Flash: %w", err)
+	}
+	return gcm, nil
+}
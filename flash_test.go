@@ -0,0 +1,187 @@
+package zvalidate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestFlash(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+
+	tests := []Flash{
+		{Key: key},
+		{Key: key, EncryptKey: key[:16]},
+		{Key: key, EncryptKey: key},
+	}
+
+	for i, f := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.Ordered(true)
+			v.Required("name", "")
+			v.Email("email", "x")
+			v.Warn("url", "no https")
+			form := url.Values{"name": {""}, "email": {"x"}, "tags": {"a", "b"}}
+
+			enc, err := f.Encode(&v, form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.EncryptKey != nil && strings.Contains(enc, "email") {
+				t.Error("not encrypted?")
+			}
+
+			haveV, haveForm, err := f.Decode(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(haveV.String(), v.String()); d != "" {
+				t.Error(d)
+			}
+			if d := ztest.Diff(haveForm.Encode(), form.Encode()); d != "" {
+				t.Error(d)
+			}
+			if d := ztest.Diff(string(TemplateError("email", haveV)),
+				`<span class="err" id="err-email">Error: must be a valid email address</span>`); d != "" {
+				t.Error(d)
+			}
+
+			// Tamper with every byte.
+			for j := range enc {
+				b := []byte(enc)
+				b[j] = map[bool]byte{true: 'A', false: 'B'}[b[j] != 'A']
+				_, _, err := f.Decode(string(b))
+				if !errors.Is(err, ErrFlashInvalid) {
+					t.Fatalf("byte %d: wrong error: %v", j, err)
+				}
+			}
+
+			// Wrong keys.
+			{
+				f2 := f
+				f2.Key = []byte("x")
+				_, _, err := f2.Decode(enc)
+				if !errors.Is(err, ErrFlashInvalid) {
+					t.Errorf("wrong error: %v", err)
+				}
+			}
+			if f.EncryptKey != nil {
+				f2 := f
+				f2.EncryptKey = make([]byte, len(f.EncryptKey))
+				_, _, err := f2.Decode(enc)
+				if !errors.Is(err, ErrFlashInvalid) {
+					t.Errorf("wrong error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFlashExclude(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	form := url.Values{"email": {"x"}, "Password": {"hunter2"}, "new_password2": {"x"}, "card_number": {"4111"}, "csrf_token": {"t"}}
+
+	tests := []struct {
+		exclude []string
+		want    string
+	}{
+		{nil, "email=x"},
+		{[]string{}, form.Encode()},
+		{[]string{"EMAIL"}, "Password=hunter2&card_number=4111&csrf_token=t&new_password2=x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.exclude), func(t *testing.T) {
+			f := Flash{Key: key, Exclude: tt.exclude}
+			v := New()
+			enc, err := f.Encode(&v, form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, have, err := f.Decode(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(have.Encode(), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	if len(form) != 5 {
+		t.Errorf("form was modified: %v", form)
+	}
+}
+
+func TestFlashErrors(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	v := New()
+	v.Required("name", "")
+
+	t.Run("no key", func(t *testing.T) {
+		_, err := Flash{}.Encode(&v, nil)
+		if err == nil {
+			t.Error("err is nil")
+		}
+		_, _, err = Flash{}.Decode("x")
+		if err == nil {
+			t.Error("err is nil")
+		}
+	})
+
+	t.Run("invalid encrypt key", func(t *testing.T) {
+		_, err := Flash{Key: key, EncryptKey: []byte("x")}.Encode(&v, nil)
+		if err == nil || !strings.Contains(err.Error(), "invalid key size") {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		f := Flash{Key: key, MaxSize: 100}
+		_, err := f.Encode(&v, url.Values{"x": {strings.Repeat("€x", 200)}})
+		if !errors.Is(err, ErrFlashTooLarge) {
+			t.Errorf("wrong error: %v", err)
+		}
+
+		_, _, err = f.Decode(strings.Repeat("x", 101))
+		if !errors.Is(err, ErrFlashTooLarge) {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		f := Flash{Key: key}
+		enc, err := f.Encode(&v, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.MaxAge = time.Hour
+		_, _, err = f.Decode(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.MaxAge = time.Nanosecond
+		time.Sleep(10 * time.Millisecond)
+		_, _, err = f.Decode(enc)
+		if !errors.Is(err, ErrFlashExpired) {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		for _, in := range []string{"", "x", "€€€", strings.Repeat("A", 50)} {
+			_, _, err := Flash{Key: key}.Decode(in)
+			if !errors.Is(err, ErrFlashInvalid) {
+				t.Errorf("%q: wrong error: %v", in, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package zvalidate
+
+import "net/url"
+
+// Form is a submitted form: the values and the validation errors.
+//
+// This is intended to re-render a form after it failed validation, for
+// example:
+//
+//	<input name="email" value="{{.Form.Value "email"}}"
+//	       {{if .Form.Invalid "email"}}class="invalid"{{end}}>
+//	{{range .Form.Errors "email"}}<span class="err">{{.}}</span>{{end}}
+//
+//	{{with .Form.Remaining}}<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}
+//
+// All methods work on a nil Form and a Form with a nil Validator, so the same
+// template can be used for the initial (empty) form.
+type Form struct {
+	Values    url.Values
+	Validator *Validator
+}
+
+// NewForm creates a new form.
+func NewForm(values url.Values, v *Validator) *Form {
+	return &Form{Values: values, Validator: v}
+}
+
+// Value gets the first submitted value for the key.
+func (f *Form) Value(key string) string {
+	if f == nil {
+		return ""
+	}
+	return f.Values.Get(key)
+}
+
+// ValueList gets all submitted values for the key.
+func (f *Form) ValueList(key string) []string {
+	if f == nil {
+		return nil
+	}
+	return f.Values[key]
+}
+
+// Invalid reports if there are errors for the key.
+func (f *Form) Invalid(key string) bool {
+	if f == nil || f.Validator == nil {
+		return false
+	}
+	return f.Validator.Failed(key)
+}
+
+// Errors gets all errors for the key, and marks them as displayed so they're
+// not included in Remaining().
+//
+// This is the same as Validator.Peek().
+func (f *Form) Errors(key string) []string {
+	if f == nil || f.Validator == nil {
+		return nil
+	}
+	return f.Validator.Peek(key)
+}
+
+// Remaining gets all errors that weren't displayed with Errors().
+//
+// This should be called after all the fields are rendered, to prevent
+// "hidden" errors.
+func (f *Form) Remaining() []FieldError {
+	if f == nil || f.Validator == nil {
+		return nil
+	}
+
+	var errs []FieldError
+	for _, k := range f.Validator.Remaining() {
+		errs = append(errs, f.Validator.fieldErrors(k)...)
+	}
+	return errs
+}
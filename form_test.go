@@ -0,0 +1,61 @@
+package zvalidate
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestForm(t *testing.T) {
+	tpl := template.Must(template.New("").Parse(`
+<input name="name" value="{{.Form.Value "name"}}"{{if .Form.Invalid "name"}} class="invalid"{{end}}>
+{{- range .Form.Errors "name"}}<span class="err">{{.}}</span>{{end}}
+<input name="email" value="{{.Form.Value "email"}}"{{if .Form.Invalid "email"}} class="invalid"{{end}}>
+{{- range .Form.Errors "email"}}<span class="err">{{.}}</span>{{end}}
+{{range .Form.ValueList "tags"}}<input name="tags" value="{{.}}">{{end}}
+{{with .Form.Remaining}}<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}`))
+
+	tests := []struct {
+		name string
+		form *Form
+		want string
+	}{
+		{"nil", nil, `
+<input name="name" value="">
+<input name="email" value="">
+
+`},
+		{"no validator", NewForm(url.Values{"name": {"x"}}, nil), `
+<input name="name" value="x">
+<input name="email" value="">
+
+`},
+		{"errors", func() *Form {
+			v := New()
+			v.Required("name", "")
+			v.Email("email", "x<y")
+			v.Append("hidden", "sneaky")
+			return NewForm(url.Values{"email": {"x<y"}, "tags": {"a", "b"}}, &v)
+		}(), `
+<input name="name" value="" class="invalid"><span class="err">must be set</span>
+<input name="email" value="x&lt;y" class="invalid"><span class="err">must be a valid email address</span>
+<input name="tags" value="a"><input name="tags" value="b">
+<ul><li>hidden: sneaky</li></ul>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			err := tpl.Execute(b, map[string]any{"Form": tt.form})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(b.String(), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// GraphQLError is a single error in the GraphQL format.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors gets all errors as GraphQL errors.
+//
+// The path is a list of field names and numeric list indexes, e.g.
+// "addresses[0].city" becomes ["addresses", 0, "city"]. The extensions have the
+// "key", and the "code" and "params" if set.
+func (v *Validator) GraphQLErrors() []GraphQLError {
+	errs := v.FieldErrors()
+	r := make([]GraphQLError, 0, len(errs))
+	for _, e := range errs {
+		ge := GraphQLError{Message: e.Message, Extensions: map[string]any{"key": e.Key}}
+		if e.Code != "" {
+			ge.Extensions["code"] = e.Code
+		}
+		if len(e.Params) > 0 {
+			ge.Extensions["params"] = e.Params
+		}
+		if len(e.Path) > 0 {
+			ge.Path = make([]any, 0, len(e.Path))
+			for _, p := range e.Path {
+				if i, err := strconv.Atoi(p.Name); p.Index && err == nil {
+					ge.Path = append(ge.Path, i)
+				} else {
+					ge.Path = append(ge.Path, p.Name)
+				}
+			}
+		}
+		r = append(r, ge)
+	}
+	return r
+}
+
+// GraphQL reports the errors in the GraphQL response format: {"errors": [..]}.
+func (v Validator) GraphQL() ([]byte, error) {
+	return json.Marshal(struct {
+		Errors []GraphQLError `json:"errors"`
+	}{v.GraphQLErrors()})
+}
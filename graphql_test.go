@@ -0,0 +1,31 @@
+package zvalidate
+
+import (
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestGraphQL(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Len("name", "", 2, 0)
+	v.Append("", "global")
+	addr := New()
+	addr.Required("city", "")
+	v.Sub("addresses", "0", addr)
+	v.Sub("other", "home", addr)
+
+	j, err := v.GraphQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"errors":[` +
+		`{"message":"must be longer than 2 characters","path":["name"],"extensions":{"code":"len_too_short","key":"name","params":{"max":0,"min":2}}},` +
+		`{"message":"global","extensions":{"key":""}},` +
+		`{"message":"must be set","path":["addresses",0,"city"],"extensions":{"code":"required","key":"addresses[0].city"}},` +
+		`{"message":"must be set","path":["other","home","city"],"extensions":{"code":"required","key":"other[home].city"}}]}`
+	if d := ztest.Diff(string(j), want); d != "" {
+		t.Error(d)
+	}
+}
@@ -0,0 +1,131 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Content types for WriteHTTP(), in order of preference if the Accept header
+// allows more than one with the same quality.
+var httpTypes = []string{"application/json", ProblemContentType, "text/html", "text/plain"}
+
+// WriteHTTP writes the errors to w, in the format from the request's Accept
+// header:
+//
+//	application/json           MarshalJSON()
+//	application/problem+json   ProblemJSON()
+//	text/html                  HTML()
+//	text/plain                 String()
+//
+// JSON is used if the Accept header is missing or doesn't list any of these.
+// The status code is Code(), or the status from Problem() for problem+json.
+//
+// It returns an error if the errors can't be encoded; nothing is written to w
+// in that case.
+func (v *Validator) WriteHTTP(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ct     = negotiate(r.Header.Get("Accept"))
+		status = v.Code()
+		body   []byte
+		err    error
+	)
+	switch ct {
+	case "application/json":
+		body, err = json.Marshal(v)
+	case ProblemContentType:
+		p := v.Problem(Problem{})
+		status = p.Status
+		body, err = json.Marshal(p)
+	case "text/html":
+		body = []byte(v.HTML())
+	case "text/plain":
+		body = []byte(v.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	h := w.Header()
+	if ct != ProblemContentType {
+		ct += "; charset=utf-8"
+	}
+	h.Set("Content-Type", ct)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Add("Vary", "Accept")
+	w.WriteHeader(status)
+	w.Write(body)
+	return nil
+}
+
+// negotiate gets the content type from httpTypes with the highest quality in
+// the Accept header.
+func negotiate(accept string) string {
+	best, bestQ := httpTypes[0], 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, _ := strings.Cut(part, ";")
+		mt = strings.ToLower(strings.TrimSpace(mt))
+
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			if k, val, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.EqualFold(k, "q") {
+				q, _ = strconv.ParseFloat(val, 64)
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		for _, t := range httpTypes {
+			if mt == t || mt == "*/*" || (strings.HasSuffix(mt, "/*") && strings.HasPrefix(t, mt[:len(mt)-1])) {
+				best, bestQ = t, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// HTTPErrorHandler is called by HandlerFunc for errors that aren't a Validator.
+//
+// The default is to write a 500 Internal Server Error; you usually want to set
+// this to something that also logs the error.
+var HTTPErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// HandlerFunc is a http.Handler that can return an error.
+//
+// A Validator is written with WriteHTTP(), and any other error (including
+// internal errors from Check()) is passed to HTTPErrorHandler. For example:
+//
+//	http.Handle("/user", zvalidate.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//	    v := zvalidate.New()
+//	    v.Required("email", r.Form.Get("email"))
+//	    if v.HasErrors() {
+//	        return &v
+//	    }
+//	    // ...
+//	    return nil
+//	}))
+type HandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// ServeHTTP calls f(w, r) and writes any error it returns.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := f(w, r)
+	if err == nil {
+		return
+	}
+
+	if v := As(err); v != nil {
+		if ierr := v.InternalError(); ierr != nil {
+			err = ierr
+		} else if v.HasErrors() {
+			if err = v.WriteHTTP(w, r); err == nil {
+				return
+			}
+		}
+	}
+	HTTPErrorHandler(w, r, err)
+}
@@ -0,0 +1,134 @@
+package zvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestWriteHTTP(t *testing.T) {
+	tests := []struct {
+		accept, wantCT, wantBody string
+		wantStatus               int
+	}{
+		{"", "application/json; charset=utf-8", `{"errors":{"email":["must be set"]}}`, 400},
+		{"*/*", "application/json; charset=utf-8", `{"errors":{"email":["must be set"]}}`, 400},
+		{"application/json", "application/json; charset=utf-8", `{"errors":{"email":["must be set"]}}`, 400},
+		{"image/png", "application/json; charset=utf-8", `{"errors":{"email":["must be set"]}}`, 400},
+		{"application/problem+json, application/json;q=0.9", "application/problem+json",
+			`{"title":"Your request parameters didn't validate.","status":400,"invalid-params":[{"name":"email","code":"required","reason":"must be set"}]}`, 400},
+		{"text/html,application/xhtml+xml,*/*;q=0.8", "text/html; charset=utf-8",
+			"<ul class='zvalidate'>\n<li><strong>email</strong>: must be set.</li>\n</ul>\n", 400},
+		{"text/*", "text/html; charset=utf-8",
+			"<ul class='zvalidate'>\n<li><strong>email</strong>: must be set.</li>\n</ul>\n", 400},
+		{"text/html;q=0.5, text/plain", "text/plain; charset=utf-8", "email: must be set.", 400},
+		{"TEXT/PLAIN;Q=1", "text/plain; charset=utf-8", "email: must be set.", 400},
+		{"text/plain;q=0, application/json;q=0.1", "application/json; charset=utf-8", `{"errors":{"email":["must be set"]}}`, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			v := New()
+			v.Required("email", "")
+
+			r := httptest.NewRequest("POST", "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			rr := httptest.NewRecorder()
+			err := v.WriteHTTP(rr, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status: %d", rr.Code)
+			}
+			if d := ztest.Diff(rr.Header().Get("Content-Type"), tt.wantCT); d != "" {
+				t.Error(d)
+			}
+			if d := ztest.Diff(rr.Body.String(), tt.wantBody); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPProblemStatus(t *testing.T) {
+	defer func(s int) { DefaultProblem.Status = s }(DefaultProblem.Status)
+	DefaultProblem.Status = 422
+
+	v := New()
+	v.Required("email", "")
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Accept", ProblemContentType)
+	rr := httptest.NewRecorder()
+	v.WriteHTTP(rr, r)
+	if rr.Code != 422 {
+		t.Errorf("status: %d", rr.Code)
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        func() error
+		wantStatus int
+		wantBody   string
+	}{
+		{"nil", func() error { return nil }, 200, "ok"},
+		{"pointer", func() error {
+			v := New()
+			v.Required("email", "")
+			return &v
+		}, 400, "email: must be set."},
+		{"value", func() error {
+			v := New()
+			v.Required("email", "")
+			return v
+		}, 400, "email: must be set."},
+		{"wrapped", func() error {
+			v := New()
+			v.Required("email", "")
+			return fmt.Errorf("wrap: %w", v.ErrorOrNil())
+		}, 400, "email: must be set."},
+		{"no errors", func() error {
+			v := New()
+			return &v
+		}, 500, "Internal Server Error\n"},
+		{"internal", func() error {
+			v := New()
+			v.Required("email", "")
+			v.Check(context.Background(), "x", func(context.Context) (string, error) { return "", errors.New("oh noes") })
+			return &v
+		}, 500, "Internal Server Error\n"},
+		{"other", func() error { return errors.New("oh noes") }, 500, "Internal Server Error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				err := tt.err()
+				if err == nil {
+					w.Write([]byte("ok"))
+				}
+				return err
+			})
+
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", "text/plain")
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, r)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status: %d", rr.Code)
+			}
+			if d := ztest.Diff(rr.Body.String(), tt.wantBody); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type (
+	// JSONAPIError is a single error object in the JSON:API format.
+	JSONAPIError struct {
+		Status string              `json:"status,omitempty"`
+		Code   string              `json:"code,omitempty"`
+		Detail string              `json:"detail"`
+		Source *JSONAPIErrorSource `json:"source,omitempty"`
+	}
+
+	// JSONAPIErrorSource is the source of a JSONAPIError.
+	JSONAPIErrorSource struct {
+		Pointer string `json:"pointer"`
+	}
+)
+
+// JSONAPIErrors gets all errors as JSON:API error objects.
+//
+// The source pointer is the path relative to /data/attributes, e.g.
+// "addresses[0].city" becomes "/data/attributes/addresses/0/city". Errors
+// without a key have no source.
+func (v *Validator) JSONAPIErrors() []JSONAPIError {
+	var (
+		errs   = v.FieldErrors()
+		status = strconv.Itoa(v.Code())
+		r      = make([]JSONAPIError, 0, len(errs))
+	)
+	for _, e := range errs {
+		je := JSONAPIError{Status: status, Code: e.Code, Detail: e.Message}
+		if len(e.Path) > 0 {
+			je.Source = &JSONAPIErrorSource{Pointer: "/data/attributes" + e.Path.JSONPointer()}
+		}
+		r = append(r, je)
+	}
+	return r
+}
+
+// JSONAPI reports the errors as a JSON:API document: {"errors": [..]}.
+func (v Validator) JSONAPI() ([]byte, error) {
+	return json.Marshal(struct {
+		Errors []JSONAPIError `json:"errors"`
+	}{v.JSONAPIErrors()})
+}
@@ -0,0 +1,42 @@
+package zvalidate
+
+import (
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestJSONAPI(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Required("name", "")
+	v.Append("", "global")
+	addr := New()
+	addr.Required("city", "")
+	v.Sub("addresses", "0", addr)
+	v.Append("a/b", "escaped")
+
+	j, err := v.JSONAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"errors":[` +
+		`{"status":"400","code":"required","detail":"must be set","source":{"pointer":"/data/attributes/name"}},` +
+		`{"status":"400","detail":"global"},` +
+		`{"status":"400","code":"required","detail":"must be set","source":{"pointer":"/data/attributes/addresses/0/city"}},` +
+		`{"status":"400","detail":"escaped","source":{"pointer":"/data/attributes/a~1b"}}]}`
+	if d := ztest.Diff(string(j), want); d != "" {
+		t.Error(d)
+	}
+
+	{
+		v := New()
+		j, err := v.JSONAPI()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := ztest.Diff(string(j), `{"errors":[]}`); d != "" {
+			t.Error(d)
+		}
+	}
+}
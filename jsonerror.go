@@ -0,0 +1,156 @@
+package zvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSONError adds an error for errors from encoding/json's Unmarshal() or
+// Decoder.Decode().
+//
+// This translates *json.UnmarshalTypeError, *json.SyntaxError, and the
+// "unknown field" error from Decoder.DisallowUnknownFields() to an error for
+// the field, using the messages set with Messages(). For example:
+//
+//	err := json.Unmarshal(body, &user)
+//	if err != nil {
+//	    v := zvalidate.New()
+//	    err = v.JSONError(err, body)
+//	    if err != nil {
+//	        return err // Some other error.
+//	    }
+//	    return v
+//	}
+//
+// The input data is used to get the line and column of syntax errors. Syntax
+// errors are added without a key, and unknown fields with just the field name
+// as encoding/json doesn't report the full path for them.
+//
+// It returns the error as-is if it can't be translated, or nil if it was added.
+func (v *Validator) JSONError(err error, data []byte) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		typeErr *json.UnmarshalTypeError
+		synErr  *json.SyntaxError
+	)
+	switch {
+	case errors.As(err, &typeErr):
+		v.jsonTypeError(typeErr)
+	case errors.As(err, &synErr):
+		v.jsonSyntaxError(data, int(synErr.Offset)-1, synErr.Error())
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		v.jsonSyntaxError(data, len(data), io.ErrUnexpectedEOF.Error())
+	default:
+		field, ok := unknownField(err.Error())
+		if !ok {
+			return err
+		}
+		v.appendError(field, CodeUnexpected, v.msg.Unexpected(), nil)
+	}
+	return nil
+}
+
+// unknownField gets the field name from the error for DisallowUnknownFields(),
+// which may be wrapped with text before or after it.
+func unknownField(msg string) (string, bool) {
+	_, rest, ok := strings.Cut(msg, `json: unknown field "`)
+	if !ok {
+		return "", false
+	}
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '"':
+			f, err := strconv.Unquote(`"` + rest[:i+1])
+			return f, err == nil
+		}
+	}
+	return "", false
+}
+
+func (v *Validator) jsonTypeError(err *json.UnmarshalTypeError) {
+	var p Path
+	if err.Field != "" {
+		for _, f := range strings.Split(err.Field, ".") {
+			_, nerr := strconv.Atoi(f)
+			p = append(p, PathElem{Name: f, Index: nerr == nil})
+		}
+	}
+
+	t := err.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		v.appendPath(p, CodeBool, v.msg.Bool(), nil)
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := strings.CutPrefix(err.Value, "number ")
+		if ok && !strings.ContainsAny(n, ".eE") {
+			v.jsonRangeError(p, t, n)
+			return
+		}
+		v.appendPath(p, CodeInteger, v.msg.Integer(), nil)
+		return
+	}
+
+	typ := "object"
+	switch t.Kind() {
+	case reflect.String:
+		typ = "string"
+	case reflect.Float32, reflect.Float64:
+		typ = "number"
+	case reflect.Slice, reflect.Array:
+		typ = "array"
+		if t.Elem().Kind() == reflect.Uint8 {
+			typ = "string"
+		}
+	}
+	v.appendPath(p, CodeJSONType, fmt.Sprintf(v.msg.JSONType(), typ), map[string]any{"type": typ})
+}
+
+// jsonRangeError adds an error for an integer that doesn't fit in the type.
+func (v *Validator) jsonRangeError(p Path, t reflect.Type, n string) {
+	var (
+		lo     any = int64(0)
+		hi     any
+		bits   = t.Bits()
+		signed = t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64
+	)
+	if signed {
+		lo, hi = int64(-1)<<(bits-1), int64(1)<<(bits-1)-1
+	} else {
+		hi = uint64(math.MaxUint64) >> (64 - bits)
+	}
+
+	params := map[string]any{"min": lo, "max": hi}
+	if strings.HasPrefix(n, "-") {
+		v.appendPath(p, CodeRangeTooLow, fmt.Sprintf(v.msg.RangeHigher(), lo), params)
+	} else {
+		v.appendPath(p, CodeRangeTooHigh, fmt.Sprintf(v.msg.RangeLower(), hi), params)
+	}
+}
+
+// jsonSyntaxError adds an error for a syntax error at the byte offset.
+func (v *Validator) jsonSyntaxError(data []byte, offset int, reason string) {
+	offset = max(0, min(offset, len(data)))
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	col := utf8.RuneCount(data[bytes.LastIndexByte(data[:offset], '\n')+1:offset]) + 1
+
+	v.appendError("", CodeJSONSyntax, fmt.Sprintf(v.msg.JSONSyntax(), line, col),
+		map[string]any{"line": line, "column": col, "offset": offset, "reason": reason})
+}
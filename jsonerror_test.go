@@ -0,0 +1,110 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestJSONError(t *testing.T) {
+	type T struct {
+		Age   int     `json:"age"`
+		Small int8    `json:"small"`
+		Count uint16  `json:"count"`
+		Admin *bool   `json:"admin"`
+		Price float64 `json:"price"`
+		Name  string  `json:"name"`
+		Data  []byte  `json:"data"`
+		Tags  []string
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{`{"age":"x"}`, `[{"key":"age","code":"integer","message":"must be a whole number"}]`},
+		{`{"age":1.5}`, `[{"key":"age","code":"integer","message":"must be a whole number"}]`},
+		{`{"small":200}`, `[{"key":"small","code":"range_too_high","params":{"max":127,"min":-128},"message":"must be 127 or lower"}]`},
+		{`{"count":-1}`, `[{"key":"count","code":"range_too_low","params":{"max":65535,"min":0},"message":"must be 0 or higher"}]`},
+		{`{"admin":1}`, `[{"key":"admin","code":"bool","message":"must be a boolean"}]`},
+		{`{"price":"1"}`, `[{"key":"price","code":"json_type","params":{"type":"number"},"message":"must be of type number"}]`},
+		{`{"name":1}`, `[{"key":"name","code":"json_type","params":{"type":"string"},"message":"must be of type string"}]`},
+		{`{"data":1}`, `[{"key":"data","code":"json_type","params":{"type":"string"},"message":"must be of type string"}]`},
+		{`{"Tags":{}}`, `[{"key":"Tags","code":"json_type","params":{"type":"array"},"message":"must be of type array"}]`},
+		{`{"items":[{}, {"name":1}]}`, `[{"key":"items[1].name","code":"json_type","params":{"type":"string"},"message":"must be of type string"}]`},
+		{`[]`, `[{"key":"","code":"json_type","params":{"type":"object"},"message":"must be of type object"}]`},
+		{`{"unknown":1}`, `[{"key":"unknown","code":"unexpected","message":"unknown parameter"}]`},
+		{"{\n  \"age\": 1,\n  \"naïve\": x}",
+			`[{"key":"","code":"json_syntax","params":{"column":12,"line":3,"offset":26,"reason":"invalid character 'x' looking for beginning of value"},"message":"invalid JSON on line 3, column 12"}]`},
+		{"{\n\"age\":",
+			`[{"key":"","code":"json_syntax","params":{"column":7,"line":2,"offset":8,"reason":"unexpected EOF"},"message":"invalid JSON on line 2, column 7"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var x T
+			d := json.NewDecoder(strings.NewReader(tt.in))
+			d.DisallowUnknownFields()
+			err := d.Decode(&x)
+
+			v := New()
+			err = v.JSONError(fmt.Errorf("wrap: %w", err), []byte(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(mustJSON(t, v.FieldErrors()), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestJSONErrorUnknownField(t *testing.T) {
+	tests := []struct {
+		in   error
+		want string
+	}{
+		{errors.New(`json: unknown field "x"`), "x"},
+		{fmt.Errorf("decode: %w: in body", errors.New(`json: unknown field "x"`)), "x"},
+		{fmt.Errorf("decode: %w: in body", fmt.Errorf("json: unknown field %q", `a"b\c`)), `a"b\c`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in.Error(), func(t *testing.T) {
+			v := New()
+			err := v.JSONError(tt.in, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(mustJSON(t, v.Keys()), mustJSON(t, []string{tt.want})); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestJSONErrorOther(t *testing.T) {
+	v := New()
+	if err := v.JSONError(nil, nil); err != nil {
+		t.Error(err)
+	}
+
+	want := errors.New("oh noes")
+	if err := v.JSONError(want, nil); err != want {
+		t.Error(err)
+	}
+
+	want = fmt.Errorf("decode: %w: in body", errors.New(`json: unknown field "x`))
+	if err := v.JSONError(want, nil); err != want {
+		t.Error(err)
+	}
+	if v.HasErrors() {
+		t.Error(v)
+	}
+}
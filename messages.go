@@ -24,6 +24,24 @@ type Messages struct {
 	RangeLower         func() string
 	UTF8               func() string
 	Contains           func() string
+	Unexpected         func() string
+	Duplicate          func() string
+	JSONType           func() string
+	JSONSyntax         func() string
+	NumberHigher       func() string
+	NumberHigherThan   func() string
+	NumberLower        func() string
+	NumberLowerThan    func() string
+	NumberBetween      func() string
+	MultipleOf         func() string
+	Float              func() string
+	Decimal            func() string
+	DecimalInt         func() string
+	DecimalFrac        func() string
+
+	// Message for non-Validator errors passed to Sub(); if this is nil then
+	// the error's Error() text is used.
+	Cause func() string
 }
 
 var DefaultMessages = Messages{
@@ -50,6 +68,20 @@ var DefaultMessages = Messages{
 	RangeLower:         func() string { return "must be %d or lower" },
 	UTF8:               func() string { return "must be UTF-8" },
 	Contains:           func() string { return "cannot contain the characters %s" },
+	Unexpected:         func() string { return "unknown parameter" },
+	Duplicate:          func() string { return "cannot be given more than once" },
+	JSONType:           func() string { return "must be of type %s" },
+	JSONSyntax:         func() string { return "invalid JSON on line %d, column %d" },
+	NumberHigher:       func() string { return "must be %s or higher" },
+	NumberHigherThan:   func() string { return "must be higher than %s" },
+	NumberLower:        func() string { return "must be %s or lower" },
+	NumberLowerThan:    func() string { return "must be lower than %s" },
+	NumberBetween:      func() string { return "must be between %s and %s" },
+	MultipleOf:         func() string { return "must be a multiple of %s" },
+	Float:              func() string { return "must be a number" },
+	Decimal:            func() string { return "must be a decimal number" },
+	DecimalInt:         func() string { return "must have at most %d digits before the decimal point" },
+	DecimalFrac:        func() string { return "must have at most %d decimal places" },
 }
 
 func (v Validator) getMessage(in []string, f func() string) string {
@@ -17,3 +17,17 @@ func TestTranslate(t *testing.T) {
 		t.Errorf("\nhave: %s\nwant: %s", have, want)
 	}
 }
+
+func TestMessagesDefault(t *testing.T) {
+	v := New()
+	v.Messages(Messages{})
+	v.Hex("hex", "x")
+	v.Octal("octal", "x")
+	v.PhoneInternational("phone", "x")
+
+	have := fmt.Sprintf("%v", v.Errors)
+	want := `map[hex:[must be a whole number in base 16 (hexadecimal)] octal:[must be a whole number in base 8 (octal)] phone:[must be a valid phone number with country dialing prefix]]`
+	if have != want {
+		t.Errorf("\nhave: %s\nwant: %s", have, want)
+	}
+}
@@ -0,0 +1,139 @@
+package zvalidate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Number is a constraint for the numeric types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// These are functions rather than methods on Validator as methods can't have
+// type parameters.
+
+// Min validates that the value is min or higher.
+//
+// Unlike Range() this works for all numeric types, and there is no special
+// value for "no limit": use just Min() or Max() for a range that's unbounded
+// on one side. NaN is never valid.
+func Min[T Number](v *Validator, key string, value, min T, message ...string) {
+	if !(value >= min) {
+		v.appendError(key, CodeRangeTooLow, fmt.Sprintf(v.getMessage(message, v.msg.NumberHigher), formatNumber(min)),
+			map[string]any{"min": paramNumber(min)})
+	}
+}
+
+// MinExclusive validates that the value is higher than min.
+func MinExclusive[T Number](v *Validator, key string, value, min T, message ...string) {
+	if !(value > min) {
+		v.appendError(key, CodeRangeTooLow, fmt.Sprintf(v.getMessage(message, v.msg.NumberHigherThan), formatNumber(min)),
+			map[string]any{"exclusive_min": paramNumber(min)})
+	}
+}
+
+// Max validates that the value is max or lower.
+func Max[T Number](v *Validator, key string, value, max T, message ...string) {
+	if !(value <= max) {
+		v.appendError(key, CodeRangeTooHigh, fmt.Sprintf(v.getMessage(message, v.msg.NumberLower), formatNumber(max)),
+			map[string]any{"max": paramNumber(max)})
+	}
+}
+
+// MaxExclusive validates that the value is lower than max.
+func MaxExclusive[T Number](v *Validator, key string, value, max T, message ...string) {
+	if !(value < max) {
+		v.appendError(key, CodeRangeTooHigh, fmt.Sprintf(v.getMessage(message, v.msg.NumberLowerThan), formatNumber(max)),
+			map[string]any{"exclusive_max": paramNumber(max)})
+	}
+}
+
+// Between validates that the value is between min and max, inclusive.
+//
+// This is like calling Min() and Max(), except that the message has both
+// bounds: "must be between %s and %s".
+func Between[T Number](v *Validator, key string, value, min, max T, message ...string) {
+	var code string
+	switch {
+	case !(value >= min):
+		code = CodeRangeTooLow
+	case !(value <= max):
+		code = CodeRangeTooHigh
+	default:
+		return
+	}
+	v.appendError(key, code, fmt.Sprintf(v.getMessage(message, v.msg.NumberBetween), formatNumber(min), formatNumber(max)),
+		map[string]any{"min": paramNumber(min), "max": paramNumber(max)})
+}
+
+// MultipleOf validates that the value is a multiple of step.
+//
+// Floats are compared with a small tolerance, so that 0.3 is a multiple of
+// 0.1. It will panic if step is 0 or lower.
+func MultipleOf[T Number](v *Validator, key string, value, step T, message ...string) {
+	if !(step > 0) {
+		panic(fmt.Sprintf("zvalidate.MultipleOf: step must be higher than 0: %v", step))
+	}
+
+	var ok bool
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Float32:
+		// float32 only has ~7 significant digits.
+		q := float64(value) / float64(step)
+		ok = math.Abs(q-math.Round(q)) <= 1e-6*math.Max(1, math.Abs(q))
+	case reflect.Float64:
+		q := float64(value) / float64(step)
+		ok = math.Abs(q-math.Round(q)) <= 1e-9*math.Max(1, math.Abs(q))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ok = int64(value)%int64(step) == 0
+	default:
+		ok = uint64(value)%uint64(step) == 0
+	}
+	if !ok {
+		v.appendError(key, CodeMultipleOf, fmt.Sprintf(v.getMessage(message, v.msg.MultipleOf), formatNumber(step)),
+			map[string]any{"step": paramNumber(step)})
+	}
+}
+
+// paramNumber converts a number to int64, uint64, or float64 for the Params, so
+// named types can be encoded with encoding/gob.
+func paramNumber[T Number](n T) any {
+	switch reflect.ValueOf(n).Kind() {
+	case reflect.Float32:
+		// So that float32(0.1) is 0.1 and not 0.10000000149011612.
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(n), 'g', -1, 32), 64)
+		return f
+	case reflect.Float64:
+		return float64(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int64(n)
+	default:
+		return uint64(n)
+	}
+}
+
+// formatNumber formats a number for display in messages, without exponents or
+// trailing zeros for all but very large or very small floats.
+func formatNumber[T Number](n T) string {
+	switch reflect.ValueOf(n).Kind() {
+	case reflect.Float32, reflect.Float64:
+		bits := 64
+		if reflect.ValueOf(n).Kind() == reflect.Float32 {
+			bits = 32
+		}
+		f := float64(n)
+		if a := math.Abs(f); a >= 1e21 || (a != 0 && a < 1e-6) {
+			return strconv.FormatFloat(f, 'g', -1, bits)
+		}
+		return strconv.FormatFloat(f, 'f', -1, bits)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(int64(n), 10)
+	default:
+		return strconv.FormatUint(uint64(n), 10)
+	}
+}
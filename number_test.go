@@ -0,0 +1,107 @@
+package zvalidate
+
+import (
+	"math"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestNumber(t *testing.T) {
+	type celsius float64
+
+	tests := []struct {
+		val  func(*Validator)
+		want string
+	}{
+		{func(v *Validator) {
+			Min(v, "k", 0, 0)
+			Min(v, "k", 0.5, 0.1)
+			Max(v, "k", -1, 0)
+			Max(v, "k", uint8(255), 255)
+			MinExclusive(v, "k", 0.1, 0)
+			MaxExclusive(v, "k", -0.1, 0)
+			Between(v, "k", celsius(-10.5), -20, 50)
+			MultipleOf(v, "k", 10, 5)
+			MultipleOf(v, "k", 0.3, 0.1)
+			MultipleOf(v, "k", 1.15, 0.05)
+			MultipleOf(v, "k", float32(0.3), float32(0.1))
+			MultipleOf(v, "k", float32(1.15), float32(0.05))
+			MultipleOf(v, "k", uint(0), 3)
+		}, `null`},
+
+		{func(v *Validator) { Min(v, "k", -1, 0) },
+			`[{"key":"k","code":"range_too_low","params":{"min":0},"message":"must be 0 or higher"}]`},
+		{func(v *Validator) { Min(v, "k", 0.0999, 0.1) },
+			`[{"key":"k","code":"range_too_low","params":{"min":0.1},"message":"must be 0.1 or higher"}]`},
+		{func(v *Validator) { Min(v, "k", float32(0), 0.1) },
+			`[{"key":"k","code":"range_too_low","params":{"min":0.1},"message":"must be 0.1 or higher"}]`},
+		{func(v *Validator) { Min(v, "k", math.NaN(), 0) },
+			`[{"key":"k","code":"range_too_low","params":{"min":0},"message":"must be 0 or higher"}]`},
+		{func(v *Validator) { MinExclusive(v, "k", 0, 0) },
+			`[{"key":"k","code":"range_too_low","params":{"exclusive_min":0},"message":"must be higher than 0"}]`},
+		{func(v *Validator) { Max(v, "k", 1, 0) },
+			`[{"key":"k","code":"range_too_high","params":{"max":0},"message":"must be 0 or lower"}]`},
+		{func(v *Validator) { Max(v, "k", uint64(math.MaxUint64), math.MaxUint64-1) },
+			`[{"key":"k","code":"range_too_high","params":{"max":18446744073709551614},"message":"must be 18446744073709551614 or lower"}]`},
+		{func(v *Validator) { MaxExclusive(v, "k", 1e22, 1e21) },
+			`[{"key":"k","code":"range_too_high","params":{"exclusive_max":1e+21},"message":"must be lower than 1e+21"}]`},
+		{func(v *Validator) { MaxExclusive(v, "k", 1, 0.0000001) },
+			`[{"key":"k","code":"range_too_high","params":{"exclusive_max":1e-7},"message":"must be lower than 1e-07"}]`},
+		{func(v *Validator) { Between(v, "k", celsius(-30), -20, 50.5) },
+			`[{"key":"k","code":"range_too_low","params":{"max":50.5,"min":-20},"message":"must be between -20 and 50.5"}]`},
+		{func(v *Validator) { Between(v, "k", 100, -20, 50) },
+			`[{"key":"k","code":"range_too_high","params":{"max":50,"min":-20},"message":"must be between -20 and 50"}]`},
+		{func(v *Validator) { Between(v, "k", math.NaN(), 0, 1) },
+			`[{"key":"k","code":"range_too_low","params":{"max":1,"min":0},"message":"must be between 0 and 1"}]`},
+		{func(v *Validator) { Between(v, "k", 100, -20, 50, "from %s to %s") },
+			`[{"key":"k","code":"range_too_high","params":{"max":50,"min":-20},"message":"from -20 to 50"}]`},
+		{func(v *Validator) { MultipleOf(v, "k", 7, 5) },
+			`[{"key":"k","code":"multiple_of","params":{"step":5},"message":"must be a multiple of 5"}]`},
+		{func(v *Validator) { MultipleOf(v, "k", 0.35, 0.1) },
+			`[{"key":"k","code":"multiple_of","params":{"step":0.1},"message":"must be a multiple of 0.1"}]`},
+		{func(v *Validator) { MultipleOf(v, "k", float32(0.35), float32(0.1)) },
+			`[{"key":"k","code":"multiple_of","params":{"step":0.1},"message":"must be a multiple of 0.1"}]`},
+		{func(v *Validator) { MultipleOf(v, "k", -7, 5) },
+			`[{"key":"k","code":"multiple_of","params":{"step":5},"message":"must be a multiple of 5"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			v := New()
+			tt.val(&v)
+			if d := ztest.Diff(mustJSON(t, v.FieldErrors()), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestNumberGob(t *testing.T) {
+	type celsius float32
+
+	v := New()
+	Min(&v, "k", celsius(-1), 0)
+	b, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have Validator
+	err = have.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := ztest.Diff(mustJSON(t, have.FieldErrors()), mustJSON(t, v.FieldErrors())); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestMultipleOfPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("no panic")
+		}
+	}()
+	v := New()
+	MultipleOf(&v, "k", 1, 0)
+}
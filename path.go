@@ -0,0 +1,145 @@
+package zvalidate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Path to a value, such as addresses[0].city.
+	//
+	// Sub() builds paths from the key and subKey. Every FieldError has the
+	// Path, which can be rendered in different styles; the keys in
+	// Validator.Errors are the String() representation.
+	Path []PathElem
+
+	// PathElem is a single element in a Path.
+	PathElem struct {
+		Name  string
+		Index bool // Index in a list or map, rather than a field name.
+	}
+)
+
+// NewPath creates a new path.
+//
+// The elements can be a string for a field name, an int for a list index, or a
+// PathElem. It will panic on any other type.
+//
+//	zvalidate.NewPath("addresses", 0, "city")
+func NewPath(elems ...any) Path {
+	p := make(Path, 0, len(elems))
+	for _, e := range elems {
+		switch ee := e.(type) {
+		case string:
+			p = append(p, PathElem{Name: ee})
+		case int:
+			p = append(p, PathElem{Name: strconv.Itoa(ee), Index: true})
+		case PathElem:
+			p = append(p, ee)
+		default:
+			panic(fmt.Sprintf("zvalidate.NewPath: invalid type %T", e))
+		}
+	}
+	return p
+}
+
+// ParsePath parses a path in the dotted style from String(), e.g.
+// addresses[0].city.
+//
+// Everything between [ and ] is an index; an unterminated [ is added as part of
+// the name.
+func ParsePath(s string) Path {
+	var (
+		p    Path
+		name strings.Builder
+	)
+	flush := func() {
+		if name.Len() > 0 {
+			p = append(p, PathElem{Name: name.String()})
+			name.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '.':
+			flush()
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end == -1 {
+				name.WriteString(s[i:])
+				i = len(s)
+				continue
+			}
+			flush()
+			p = append(p, PathElem{Name: s[i+1 : i+end], Index: true})
+			i += end
+		default:
+			name.WriteByte(s[i])
+		}
+	}
+	flush()
+	return p
+}
+
+// keyPath gets the path for a key given to Append() and the validators.
+func keyPath(key string) Path {
+	if key == "" {
+		return nil
+	}
+	return Path{{Name: key}}
+}
+
+// join the elements to p, always returning a new Path.
+func (p Path) join(elems ...PathElem) Path {
+	n := make(Path, 0, len(p)+len(elems))
+	return append(append(n, p...), elems...)
+}
+
+// String gets the path in the dotted style used for the keys in
+// Validator.Errors, e.g. addresses[0].city.
+func (p Path) String() string {
+	var b strings.Builder
+	for i, e := range p {
+		switch {
+		case e.Index:
+			b.WriteByte('[')
+			b.WriteString(e.Name)
+			b.WriteByte(']')
+		case i == 0:
+			b.WriteString(e.Name)
+		default:
+			b.WriteByte('.')
+			b.WriteString(e.Name)
+		}
+	}
+	return b.String()
+}
+
+// JSONPointer gets the path as a RFC 6901 JSON pointer, e.g.
+// /addresses/0/city.
+func (p Path) JSONPointer() string {
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, e := range p {
+		b.WriteByte('/')
+		b.WriteString(r.Replace(e.Name))
+	}
+	return b.String()
+}
+
+// FormName gets the path in the style for HTML form names used by Rails, PHP,
+// and others, e.g. addresses[0][city].
+func (p Path) FormName() string {
+	var b strings.Builder
+	for i, e := range p {
+		if i == 0 {
+			b.WriteString(e.Name)
+			continue
+		}
+		b.WriteByte('[')
+		b.WriteString(e.Name)
+		b.WriteByte(']')
+	}
+	return b.String()
+}
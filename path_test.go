@@ -0,0 +1,97 @@
+package zvalidate
+
+import (
+	"fmt"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		in                 Path
+		str, pointer, form string
+	}{
+		{nil, "", "", ""},
+		{NewPath("name"), "name", "/name", "name"},
+		{NewPath("addresses", 0, "city"), "addresses[0].city", "/addresses/0/city", "addresses[0][city]"},
+		{NewPath("user", "address", 0, "city"), "user.address[0].city", "/user/address/0/city", "user[address][0][city]"},
+		{NewPath("a.b", "c[d]"), "a.b.c[d]", "/a.b/c[d]", "a.b[c[d]]"},
+		{NewPath("a/b", "~c"), "a/b.~c", "/a~1b/~0c", "a/b[~c]"},
+		{NewPath(PathElem{Name: "home", Index: true}), "[home]", "/home", "home"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if d := ztest.Diff(tt.in.String(), tt.str); d != "" {
+				t.Errorf("String()\n%s", d)
+			}
+			if d := ztest.Diff(tt.in.JSONPointer(), tt.pointer); d != "" {
+				t.Errorf("JSONPointer()\n%s", d)
+			}
+			if d := ztest.Diff(tt.in.FormName(), tt.form); d != "" {
+				t.Errorf("FormName()\n%s", d)
+			}
+		})
+	}
+}
+
+func TestPathSub(t *testing.T) {
+	v := New()
+	v.Required("name", "")
+
+	addr := New()
+	addr.Required("city", "")
+	addr.AppendPath(NewPath("lines", 1), "too long")
+	v.Sub("addresses", "0", addr)
+
+	s1, s2 := New(), New()
+	s2.Required("a.b", "")
+	s1.Sub("sub2", "", s2)
+	v.Sub("sub1", "x.y", s1)
+
+	v.Sub("db", "", fmt.Errorf("oh noes"))
+
+	have := ""
+	for _, e := range v.FieldErrors() {
+		have += fmt.Sprintf("%-28s %-28s %s\n", e.Key, e.Path.JSONPointer(), e.Path.FormName())
+	}
+	want := `
+addresses[0].city            /addresses/0/city            addresses[0][city]
+addresses[0].lines[1]        /addresses/0/lines/1         addresses[0][lines][1]
+db                           /db                          db
+name                         /name                        name
+sub1[x.y].sub2.a.b           /sub1/x.y/sub2/a.b           sub1[x.y][sub2][a.b]
+`[1:]
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Path
+	}{
+		{"", nil},
+		{"name", NewPath("name")},
+		{"addresses[0].city", NewPath("addresses", 0, "city")},
+		{"user.address[0][1].city", NewPath("user", "address", 0, 1, "city")},
+		{"sub[x.y].a", NewPath("sub", PathElem{Name: "x.y", Index: true}, "a")},
+		{"[home]", NewPath(PathElem{Name: "home", Index: true})},
+		{"a..b.", NewPath("a", "b")},
+		{"a[0", NewPath("a[0")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			have := ParsePath(tt.in)
+			if d := ztest.Diff(fmt.Sprintf("%#v", have), fmt.Sprintf("%#v", tt.want)); d != "" {
+				t.Error(d)
+			}
+			if have.String() != tt.in && tt.in != "a..b." {
+				t.Errorf("String(): %q", have.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package zvalidate
+
+import "encoding/json"
+
+// ProblemContentType is the media type for ProblemJSON().
+const ProblemContentType = "application/problem+json"
+
+type (
+	// Problem is a "problem details" document from RFC 9457 (previously RFC
+	// 7807), with the errors in the "invalid-params" extension.
+	Problem struct {
+		Type          string         `json:"type,omitempty"`
+		Title         string         `json:"title,omitempty"`
+		Status        int            `json:"status,omitempty"`
+		Detail        string         `json:"detail,omitempty"`
+		Instance      string         `json:"instance,omitempty"`
+		InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	}
+
+	// InvalidParam is a single error in Problem.
+	InvalidParam struct {
+		Name   string `json:"name"`
+		Code   string `json:"code,omitempty"`
+		Reason string `json:"reason"`
+	}
+)
+
+// DefaultProblem is used for the zero fields in Problem() and for
+// ProblemJSON().
+//
+// For example, to always use a status code of 422 and your own type:
+//
+//	zvalidate.DefaultProblem.Type = "https://example.com/probs/validation"
+//	zvalidate.DefaultProblem.Status = 422
+var DefaultProblem = Problem{
+	Title: "Your request parameters didn't validate.",
+}
+
+// Problem creates a problem details document for all errors.
+//
+// Any zero fields in p are set from DefaultProblem; if Status is still 0 then
+// Code() is used. DefaultProblem.Status is not used if a Check() failed with an
+// internal error, so the status is always 500 in that case.
+func (v *Validator) Problem(p Problem) Problem {
+	if p.Type == "" {
+		p.Type = DefaultProblem.Type
+	}
+	if p.Title == "" {
+		p.Title = DefaultProblem.Title
+	}
+	if p.Status == 0 && v.InternalError() == nil {
+		p.Status = DefaultProblem.Status
+	}
+	if p.Status == 0 {
+		p.Status = v.Code()
+	}
+	if p.Detail == "" {
+		p.Detail = DefaultProblem.Detail
+	}
+	if p.Instance == "" {
+		p.Instance = DefaultProblem.Instance
+	}
+
+	errs := v.FieldErrors()
+	p.InvalidParams = make([]InvalidParam, 0, len(errs))
+	for _, e := range errs {
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: e.Key, Code: e.Code, Reason: e.Message})
+	}
+	return p
+}
+
+// ProblemJSON reports the errors as a problem details document, using the
+// fields from DefaultProblem.
+//
+// This should be sent with the ProblemContentType Content-Type header.
+func (v Validator) ProblemJSON() ([]byte, error) {
+	return json.Marshal(v.Problem(Problem{}))
+}
@@ -0,0 +1,90 @@
+package zvalidate
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestProblem(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Required("name", "")
+	v.Len("name", "", 2, 0)
+	v.Append("age", "too old")
+
+	{
+		j, err := v.ProblemJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"title":"Your request parameters didn't validate.","status":400,"invalid-params":[` +
+			`{"name":"name","code":"required","reason":"must be set"},` +
+			`{"name":"name","code":"len_too_short","reason":"must be longer than 2 characters"},` +
+			`{"name":"age","reason":"too old"}]}`
+		if d := ztest.Diff(string(j), want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	{
+		p := v.Problem(Problem{
+			Type:     "https://example.com/probs/validation",
+			Status:   422,
+			Instance: "/users/1",
+		})
+		want := `{"type":"https://example.com/probs/validation","title":"Your request parameters didn't validate.",` +
+			`"status":422,"instance":"/users/1","invalid-params":[` +
+			`{"name":"name","code":"required","reason":"must be set"},` +
+			`{"name":"name","code":"len_too_short","reason":"must be longer than 2 characters"},` +
+			`{"name":"age","reason":"too old"}]}`
+		if d := ztest.Diff(mustJSON(t, p), want); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestProblemDefault(t *testing.T) {
+	defer func(p Problem) { DefaultProblem = p }(DefaultProblem)
+	DefaultProblem.Status = 422
+	DefaultProblem.Type = "https://example.com/probs/validation"
+	DefaultProblem.Detail = "oh noes"
+
+	v := New()
+	v.Required("name", "")
+
+	j, err := v.ProblemJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"https://example.com/probs/validation","title":"Your request parameters didn't validate.",` +
+		`"status":422,"detail":"oh noes","invalid-params":[{"name":"name","code":"required","reason":"must be set"}]}`
+	if d := ztest.Diff(string(j), want); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestProblemInternal(t *testing.T) {
+	defer func(p Problem) { DefaultProblem = p }(DefaultProblem)
+	DefaultProblem.Status = 422
+
+	v := New()
+	v.Check(context.Background(), "email", func(context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	})
+
+	if have := v.Problem(Problem{}).Status; have != 500 {
+		t.Errorf("status: %d", have)
+	}
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Accept", ProblemContentType)
+	rr := httptest.NewRecorder()
+	v.WriteHTTP(rr, r)
+	if rr.Code != 500 {
+		t.Errorf("WriteHTTP status: %d", rr.Code)
+	}
+}
@@ -3,25 +3,239 @@ package zvalidate
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"strings"
+	"unicode"
 )
 
+type (
+	// Templates to render errors as HTML.
+	Templates struct {
+		// Used by TemplateError(); the data is a TemplateField.
+		Error *template.Template
+
+		// Used by Validator.HTML(); the data is TemplateFields.
+		HTML *template.Template
+
+		// Used by TemplateSummary(); the data is TemplateFields.
+		Summary *template.Template
+	}
+
+	// TemplateField is the data for the errors and warnings of a single key.
+	TemplateField struct {
+		Key      string
+		ID       string // ErrorID(Key)
+		FieldID  string // FieldID(Key)
+		Errors   []string
+		Warnings []string
+	}
+
+	// TemplateFields is the data for all errors and warnings; Errors has all
+	// keys with errors and Warnings all keys with warnings.
+	TemplateFields struct {
+		Errors   []TemplateField
+		Warnings []TemplateField
+	}
+)
+
+var templateFuncs = template.FuncMap{"join": strings.Join}
+
+// DefaultTemplates are the default templates.
+var DefaultTemplates = Templates{
+	Error: template.Must(template.New("error").Funcs(templateFuncs).Parse(
+		`{{if .Errors}}<span class="err" id="{{.ID}}">Error: {{join .Errors ", "}}</span>{{end}}` +
+			`{{if .Warnings}}<span class="warn">Warning: {{join .Warnings ", "}}</span>{{end}}`)),
+
+	HTML: template.Must(template.New("html").Funcs(templateFuncs).Parse(
+		"<ul class='zvalidate'>\n" +
+			`{{range .Errors}}<li>{{if .Key}}<strong>{{.Key}}</strong>: {{end}}{{join .Errors ", "}}.</li>` + "\n{{end}}" +
+			`{{range .Warnings}}<li class='warning'>{{if .Key}}<strong>{{.Key}}</strong>: {{end}}Warning: {{join .Warnings ", "}}.</li>` + "\n{{end}}" +
+			"</ul>\n")),
+
+	Summary: template.Must(template.New("summary").Funcs(templateFuncs).Parse(
+		`<div class="error-summary" role="alert" tabindex="-1">` + "\n" +
+			"<h2>There is a problem</h2>\n<ul>\n" +
+			`{{range .Errors}}<li>{{if .Key}}<a href="#{{.FieldID}}">{{.Key}}: {{join .Errors ", "}}</a>` +
+			`{{else}}{{join .Errors ", "}}{{end}}</li>` + "\n{{end}}" +
+			"</ul>\n</div>\n")),
+}
+
+// Templates sets the templates to render the errors with.
+//
+// Any nil fields are set from DefaultTemplates.
+//
+// The templates are executed with some example data, and any template that
+// fails (e.g. because it refers to a field that doesn't exist) is replaced
+// with the template from DefaultTemplates. Templates that fail later on when
+// rendering the errors also use the DefaultTemplates instead; rendering errors
+// never panics.
+func (v *Validator) Templates(t Templates) {
+	var (
+		field  = templateField("key", []string{"error"}, []string{"warning"})
+		fields = TemplateFields{Errors: []TemplateField{field}, Warnings: []TemplateField{field}}
+	)
+	t.Error = checkTemplate(t.Error, DefaultTemplates.Error, field)
+	t.HTML = checkTemplate(t.HTML, DefaultTemplates.HTML, fields)
+	t.Summary = checkTemplate(t.Summary, DefaultTemplates.Summary, fields)
+	v.tpl = t
+}
+
+// checkTemplate returns def if t is nil or fails to execute with data.
+func checkTemplate(t, def *template.Template, data any) *template.Template {
+	if t == nil || t.Execute(io.Discard, data) != nil {
+		return def
+	}
+	return t
+}
+
+func (v *Validator) templates() Templates {
+	if v.tpl.Error == nil {
+		return DefaultTemplates
+	}
+	return v.tpl
+}
+
+// ErrorID gets the HTML id for the error message of a key, as used in the
+// default templates and TemplateAria().
+//
+// This is the key prefixed with "err-", with any whitespace replaced with "_".
+func ErrorID(key string) string {
+	return "err-" + FieldID(key)
+}
+
+// FieldID gets the HTML id for the input of a key, as used in the links in
+// TemplateSummary().
+//
+// This is the key with any whitespace replaced with "_".
+func FieldID(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func templateField(key string, errs, warns []string) TemplateField {
+	return TemplateField{Key: key, ID: ErrorID(key), FieldID: FieldID(key), Errors: errs, Warnings: warns}
+}
+
+// templateFields gets the data for the HTML and Summary templates.
+func (v *Validator) templateFields() TemplateFields {
+	var f TemplateFields
+	for _, k := range v.keys() {
+		f.Errors = append(f.Errors, templateField(k, v.Errors[k], nil))
+	}
+	if w := v.warnings(); w != nil {
+		for _, k := range w.keys() {
+			f.Warnings = append(f.Warnings, templateField(k, nil, w.Errors[k]))
+		}
+	}
+	return f
+}
+
+// execTemplate executes t, falling back to def if t fails.
+func execTemplate(t, def *template.Template, data any) template.HTML {
+	b := new(strings.Builder)
+	if err := t.Execute(b, data); err != nil && t != def {
+		b.Reset()
+		def.Execute(b, data)
+	}
+	return template.HTML(b.String())
+}
+
 // TemplateError displays validation errors for the given key.
 //
 // This will Pop() errors and modify the Validator in-place, so we can see if
 // there are any "hidden" errors later on.
+//
+// Warnings for the key are displayed after the errors, with the "warn" class.
+//
+// The error has ErrorID() as the id, for use with TemplateAria().
 func TemplateError(k string, v *Validator) template.HTML {
-	if v == nil || !v.HasErrors() {
+	if v == nil {
 		return template.HTML("")
 	}
 
 	errs := v.Pop(k)
-	if errs == nil {
+	var warns []string
+	if w := v.warnings(); w != nil {
+		warns = w.Pop(k)
+	}
+	if errs == nil && warns == nil {
+		return template.HTML("")
+	}
+	return execTemplate(v.templates().Error, DefaultTemplates.Error, templateField(k, errs, warns))
+}
+
+// TemplatePeek displays validation errors for the given key, like
+// TemplateError(), but uses Peek() instead of Pop() so the errors can be
+// displayed more than once.
+//
+// Use TemplateRemaining() to display errors that weren't displayed.
+func TemplatePeek(k string, v *Validator) template.HTML {
+	if v == nil {
 		return template.HTML("")
 	}
 
-	return template.HTML(fmt.Sprintf(`<span class="err">Error: %s</span>`,
-		template.HTMLEscapeString(strings.Join(errs, ", "))))
+	errs := v.Peek(k)
+	var warns []string
+	if w := v.warnings(); w != nil {
+		warns = w.Peek(k)
+	}
+	if errs == nil && warns == nil {
+		return template.HTML("")
+	}
+	return execTemplate(v.templates().Error, DefaultTemplates.Error, templateField(k, errs, warns))
+}
+
+// TemplateRemaining displays all errors that weren't displayed with
+// TemplatePeek(), with the same template as Validator.HTML().
+func TemplateRemaining(v *Validator) template.HTML {
+	if v == nil {
+		return template.HTML("")
+	}
+
+	var f TemplateFields
+	for _, k := range v.Remaining() {
+		f.Errors = append(f.Errors, templateField(k, v.Errors[k], nil))
+	}
+	if w := v.warnings(); w != nil {
+		for _, k := range w.Remaining() {
+			f.Warnings = append(f.Warnings, templateField(k, nil, w.Errors[k]))
+		}
+	}
+	if len(f.Errors) == 0 && len(f.Warnings) == 0 {
+		return template.HTML("")
+	}
+	return execTemplate(v.templates().HTML, DefaultTemplates.HTML, f)
+}
+
+// TemplateAria gets the aria-invalid and aria-describedby attributes for the
+// input of a key, if it has errors. For example:
+//
+//	<input name="email" {{aria "email" .Validate}}>
+//	{{validate "email" .Validate}}
+//
+// This needs to be called before TemplateError(), as that removes the errors.
+func TemplateAria(k string, v *Validator) template.HTMLAttr {
+	if v == nil || !v.Failed(k) {
+		return template.HTMLAttr("")
+	}
+	return template.HTMLAttr(fmt.Sprintf(`aria-invalid="true" aria-describedby="%s"`,
+		template.HTMLEscapeString(ErrorID(k))))
+}
+
+// TemplateSummary displays a summary of all errors, with links to the inputs.
+//
+// The links point to FieldID(), so the inputs should have that as the id. This
+// doesn't modify the Validator, so it can be used together with
+// TemplateError() as long as it's called first.
+func TemplateSummary(v *Validator) template.HTML {
+	if v == nil || !v.HasErrors() {
+		return template.HTML("")
+	}
+	return execTemplate(v.templates().Summary, DefaultTemplates.Summary, v.templateFields())
 }
 
 // TemplateHasErrors reports if there are any validation errors.
@@ -36,3 +250,26 @@ func TemplateHasErrors(v *Validator) bool {
 	}
 	return v.HasErrors()
 }
+
+// FuncMap gets all template helpers, for use with template.Funcs():
+//
+//	validate        TemplateError()
+//	validate_peek   TemplatePeek()
+//	remaining       TemplateRemaining()
+//	aria            TemplateAria()
+//	error_summary   TemplateSummary()
+//	has_errors      TemplateHasErrors()
+//	error_id        ErrorID()
+//	field_id        FieldID()
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"validate":      TemplateError,
+		"validate_peek": TemplatePeek,
+		"remaining":     TemplateRemaining,
+		"aria":          TemplateAria,
+		"error_summary": TemplateSummary,
+		"has_errors":    TemplateHasErrors,
+		"error_id":      ErrorID,
+		"field_id":      FieldID,
+	}
+}
@@ -3,7 +3,10 @@ package zvalidate
 import (
 	"fmt"
 	"html/template"
+	"strings"
 	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
 )
 
 func TestTemplateError(t *testing.T) {
@@ -15,7 +18,7 @@ func TestTemplateError(t *testing.T) {
 		{"", nil, ""},
 		{"xxx", nil, ""},
 		{"", &Validator{Errors: map[string][]string{"k": {"xx"}}}, ""},
-		{"k", &Validator{Errors: map[string][]string{"k": {"xx"}}}, `<span class="err">Error: xx</span>`},
+		{"k", &Validator{Errors: map[string][]string{"k": {"xx"}}}, `<span class="err" id="err-k">Error: xx</span>`},
 	}
 
 	for i, tt := range tests {
@@ -48,3 +51,171 @@ func TestTemplateHasErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateAria(t *testing.T) {
+	v := New()
+	v.Append("email", "oh noes")
+	v.Append("a b", "oh noes")
+
+	tests := []struct {
+		key  string
+		in   *Validator
+		want template.HTMLAttr
+	}{
+		{"email", nil, ""},
+		{"name", &v, ""},
+		{"email", &v, `aria-invalid="true" aria-describedby="err-email"`},
+		{"a b", &v, `aria-invalid="true" aria-describedby="err-a_b"`},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			out := TemplateAria(tt.key, tt.in)
+			if out != tt.want {
+				t.Errorf("\nout:  %q\nwant: %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateSummary(t *testing.T) {
+	if out := TemplateSummary(nil); out != "" {
+		t.Errorf("not empty: %q", out)
+	}
+
+	v := New()
+	v.Required("email", "")
+	v.Append("addresses[0].city", "<oh noes>")
+	v.Append("", "global")
+	v.Warn("url", "no https")
+
+	have := string(TemplateSummary(&v))
+	want := `<div class="error-summary" role="alert" tabindex="-1">
+<h2>There is a problem</h2>
+<ul>
+<li>global</li>
+<li><a href="#addresses%5b0%5d.city">addresses[0].city: &lt;oh noes&gt;</a></li>
+<li><a href="#email">email: must be set</a></li>
+</ul>
+</div>
+`
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+
+	// Should not modify the Validator.
+	if out := TemplateError("email", &v); out != `<span class="err" id="err-email">Error: must be set</span>` {
+		t.Errorf("wrong output: %q", out)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	v := New()
+	v.Templates(Templates{
+		Error: template.Must(template.New("").Parse(
+			`<p id="{{.ID}}" role="alert">{{range .Errors}}{{.}}{{end}}</p>`)),
+		HTML: template.Must(template.New("").Parse(
+			`{{range .Errors}}[{{.Key}}: {{range .Errors}}{{.}}{{end}}]{{end}}{{range .Warnings}}({{.Key}}){{end}}`)),
+	})
+	v.Append("k", "oh noes")
+	v.Warn("w", "warn")
+
+	if d := ztest.Diff(string(v.HTML()), `[k: oh noes](w)`); d != "" {
+		t.Error(d)
+	}
+	if !strings.Contains(string(TemplateSummary(&v)), `<a href="#k">k: oh noes</a>`) {
+		t.Error("not using the default summary template")
+	}
+	if d := ztest.Diff(string(TemplateError("k", &v)), `<p id="err-k" role="alert">oh noes</p>`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestTemplateFallback(t *testing.T) {
+	t.Run("Templates", func(t *testing.T) {
+		v := New()
+		v.Templates(Templates{
+			Error: template.Must(template.New("").Parse(`{{.NoSuchField}}`)),
+			HTML:  template.Must(template.New("").Parse(`{{.NoSuchField}}`)),
+		})
+		v.Append("k", "oh noes")
+
+		if have := v.HTML(); !strings.Contains(string(have), "oh noes") {
+			t.Errorf("HTML: %q", have)
+		}
+		have := TemplateError("k", &v)
+		want := `<span class="err" id="err-k">Error: oh noes</span>`
+		if d := ztest.Diff(string(have), want); d != "" {
+			t.Error(d)
+		}
+	})
+
+	// Only fails with some data, so can't be checked in Templates().
+	t.Run("exec", func(t *testing.T) {
+		v := New()
+		v.Templates(Templates{Error: template.Must(template.New("").Parse(
+			`{{if eq .Key "x"}}{{index .Errors 1}}{{else}}{{index .Errors 0}}{{end}}`))})
+		v.Append("k", "oh noes")
+		v.Append("x", "one")
+
+		have := TemplateError("k", &v)
+		if d := ztest.Diff(string(have), "oh noes"); d != "" {
+			t.Error(d)
+		}
+		have = TemplateError("x", &v)
+		want := `<span class="err" id="err-x">Error: one</span>`
+		if d := ztest.Diff(string(have), want); d != "" {
+			t.Error(d)
+		}
+	})
+}
+
+func TestTemplatePeek(t *testing.T) {
+	tpl := template.Must(template.New("").Funcs(FuncMap()).Parse(
+		`{{error_summary .}}` +
+			`<input id="{{field_id "email"}}" {{aria "email" .}}>{{validate_peek "email" .}}` + "\n" +
+			`<input id="{{field_id "name"}}" {{aria "name" .}}>{{validate_peek "name" .}}` + "\n" +
+			`{{remaining .}}`))
+
+	v := New()
+	v.Required("email", "")
+	v.Required("hidden", "")
+	v.Warn("name", "looks odd")
+	v.Warn("other", "hidden warning")
+
+	b := new(strings.Builder)
+	err := tpl.Execute(b, &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div class="error-summary" role="alert" tabindex="-1">
+<h2>There is a problem</h2>
+<ul>
+<li><a href="#email">email: must be set</a></li>
+<li><a href="#hidden">hidden: must be set</a></li>
+</ul>
+</div>
+<input id="email" aria-invalid="true" aria-describedby="err-email"><span class="err" id="err-email">Error: must be set</span>
+<input id="name" ><span class="warn">Warning: looks odd</span>
+<ul class='zvalidate'>
+<li><strong>hidden</strong>: must be set.</li>
+<li class='warning'><strong>other</strong>: Warning: hidden warning.</li>
+</ul>
+`
+	if d := ztest.Diff(b.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	// Nothing was removed.
+	if !v.Failed("email") || !v.Warnings().Failed("name") {
+		t.Error("errors were removed")
+	}
+
+	if have := TemplateRemaining(nil); have != "" {
+		t.Errorf("not empty: %q", have)
+	}
+	if have := TemplatePeek("email", nil); have != "" {
+		t.Errorf("not empty: %q", have)
+	}
+}
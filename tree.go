@@ -0,0 +1,168 @@
+package zvalidate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// TreeErrors is the key for the messages in Tree() if there are errors for both
+// a path and paths below it.
+const TreeErrors = "_errors"
+
+// Indexes larger than this are added as a map key rather than expanding a
+// list, so that e.g. Sub("x", "99999999", ..) doesn't allocate a huge list.
+const maxTreeIndex = 10_000
+
+// Tree gets all errors as a tree mirroring the structure of Sub() calls.
+//
+// Fields are added as a map[string]any, numeric indexes as a []any, and the
+// messages as a []string. For example:
+//
+//	v.Required("name", "")
+//	v.Sub("addresses", "1", addr) // With error for "city"
+//
+// Gives:
+//
+//	{
+//	    "name":      ["must be set"],
+//	    "addresses": [null, {"city": ["must be set"]}]
+//	}
+//
+// If there are errors for both a path and the paths below it then the messages
+// are added as TreeErrors. Errors without a key are added as TreeErrors on the
+// top-level map.
+func (v *Validator) Tree() map[string]any {
+	tree := make(map[string]any)
+	for _, k := range v.keys() {
+		errs := v.fieldErrors(k)
+		if len(errs) == 0 { // Set to an empty slice directly.
+			continue
+		}
+		msgs := make([]string, 0, len(errs))
+		for _, e := range errs {
+			msgs = append(msgs, e.Message)
+		}
+		tree = treeInsert(tree, errs[0].Path, msgs).(map[string]any)
+	}
+	return tree
+}
+
+func treeInsert(node any, p Path, msgs []string) any {
+	if len(p) == 0 {
+		switch n := node.(type) {
+		case nil:
+			return append([]string{}, msgs...)
+		case []string:
+			return append(n, msgs...)
+		case []any:
+			m := treeListToMap(n)
+			m[TreeErrors] = append([]string{}, msgs...)
+			return m
+		case map[string]any:
+			e, _ := n[TreeErrors].([]string)
+			n[TreeErrors] = append(e, msgs...)
+			return n
+		}
+	}
+
+	if i, err := strconv.Atoi(p[0].Name); p[0].Index && err == nil && i >= 0 && i <= maxTreeIndex {
+		switch n := node.(type) {
+		case nil:
+			l := make([]any, i+1)
+			l[i] = treeInsert(nil, p[1:], msgs)
+			return l
+		case []any:
+			if i >= len(n) {
+				n = append(n, make([]any, i-len(n)+1)...)
+			}
+			n[i] = treeInsert(n[i], p[1:], msgs)
+			return n
+		}
+	}
+
+	var m map[string]any
+	switch n := node.(type) {
+	case nil:
+		m = make(map[string]any)
+	case map[string]any:
+		m = n
+	case []string:
+		m = map[string]any{TreeErrors: n}
+	case []any:
+		m = treeListToMap(n)
+	}
+	m[p[0].Name] = treeInsert(m[p[0].Name], p[1:], msgs)
+	return m
+}
+
+func treeListToMap(l []any) map[string]any {
+	m := make(map[string]any, len(l))
+	for i, e := range l {
+		if e != nil {
+			m[strconv.Itoa(i)] = e
+		}
+	}
+	return m
+}
+
+// ParseTree creates a new Validator from a tree as returned by Tree().
+//
+// This accepts the types encoding/json decodes to ([]any for lists and
+// messages). Map keys are added as field names and list indexes as indexes, so
+// non-numeric indexes such as Sub("addresses", "home", ..) don't survive a
+// round-trip: "addresses[home].city" becomes "addresses.home.city".
+func ParseTree(tree map[string]any) (Validator, error) {
+	v := New()
+	return v, v.parseTree(tree, nil)
+}
+
+func (v *Validator) parseTree(node any, p Path) error {
+	switch n := node.(type) {
+	case nil:
+	case []string:
+		for _, m := range n {
+			v.appendPath(p, "", m, nil)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			var err error
+			if k == TreeErrors {
+				err = v.parseTree(n[k], p)
+			} else {
+				err = v.parseTree(n[k], p.join(PathElem{Name: k}))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		msgs := make([]string, 0, len(n))
+		for _, e := range n {
+			if s, ok := e.(string); ok {
+				msgs = append(msgs, s)
+			}
+		}
+		if len(msgs) == len(n) {
+			return v.parseTree(msgs, p)
+		}
+		if len(msgs) > 0 {
+			return fmt.Errorf("zvalidate.ParseTree: list at %q has both messages and values", p)
+		}
+
+		for i, e := range n {
+			err := v.parseTree(e, p.join(PathElem{Name: strconv.Itoa(i), Index: true}))
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("zvalidate.ParseTree: invalid type %T at %q", node, p)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestTree(t *testing.T) {
+	v := New()
+	v.Required("name", "")
+	v.Append("", "global")
+
+	addr := New()
+	addr.Required("city", "")
+	addr.Append("zip", "one")
+	addr.Append("zip", "two")
+	v.Sub("addresses", "1", addr)
+
+	home := New()
+	home.Required("city", "")
+	v.Sub("other", "home", home)
+
+	v.Append("settings", "invalid")
+	s := New()
+	s.Required("domain", "")
+	v.Sub("settings", "", s)
+
+	have := mustJSON(t, v.Tree())
+	want := `{"_errors":["global"],` +
+		`"addresses":[null,{"city":["must be set"],"zip":["one","two"]}],` +
+		`"name":["must be set"],` +
+		`"other":{"home":{"city":["must be set"]}},` +
+		`"settings":{"_errors":["invalid"],"domain":["must be set"]}}`
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+
+	{ // Round-trip through JSON.
+		var tree map[string]any
+		err := json.Unmarshal([]byte(have), &tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := ParseTree(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "global.\n" +
+			"addresses[1].city: must be set.\n" +
+			"addresses[1].zip: one, two.\n" +
+			"name: must be set.\n" +
+			"other.home.city: must be set.\n" +
+			"settings: invalid.\n" +
+			"settings.domain: must be set.\n"
+		if d := ztest.Diff(p.String(), want); d != "" {
+			t.Error(d)
+		}
+		if d := ztest.Diff(mustJSON(t, p.Tree()), have); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestTreeEmpty(t *testing.T) {
+	v := New()
+	if d := ztest.Diff(mustJSON(t, v.Tree()), `{}`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestTreeEmptyKey(t *testing.T) {
+	v := New()
+	v.Required("a", "")
+	v.Errors["x"] = nil
+	if d := ztest.Diff(mustJSON(t, v.Tree()), `{"a":["must be set"]}`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestTreeLarge(t *testing.T) {
+	v := New()
+	v.Sub("x", "99999999", errors.New("oh noes"))
+	if d := ztest.Diff(mustJSON(t, v.Tree()), `{"x":{"99999999":["oh noes"]}}`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestParseTreeError(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"a": 1}`, `zvalidate.ParseTree: invalid type float64 at "a"`},
+		{`{"a": ["x", {}]}`, `zvalidate.ParseTree: list at "a" has both messages and values`},
+		{`{"a": [{"b": [true]}]}`, `zvalidate.ParseTree: invalid type bool at "a[0].b[0]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			var tree map[string]any
+			err := json.Unmarshal([]byte(tt.in), &tree)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ParseTree(tree)
+			if err == nil {
+				t.Fatal("err is nil")
+			}
+			if d := ztest.Diff(err.Error(), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package zvalidate
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unexpected adds an error for every key in the form that's not in allowed.
+func (v *Validator) Unexpected(form url.Values, allowed ...string) {
+	allow := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		allow[a] = struct{}{}
+	}
+	for _, k := range sortedKeys(form) {
+		if _, ok := allow[k]; !ok {
+			v.appendError(k, CodeUnexpected, v.msg.Unexpected(), nil)
+		}
+	}
+}
+
+// Duplicate adds an error for every key that's submitted more than once.
+//
+// This applies to the given keys, or to all keys in the form if none are given.
+func (v *Validator) Duplicate(form url.Values, keys ...string) {
+	if len(keys) == 0 {
+		keys = sortedKeys(form)
+	}
+	for _, k := range keys {
+		if len(form[k]) > 1 {
+			v.appendError(k, CodeDuplicate, v.msg.Duplicate(), nil)
+		}
+	}
+}
+
+// UnexpectedJSON adds an error for every key in the decoded JSON object that's
+// not in allowed.
+//
+// Nested keys are separated with a ".", and "[]" matches any list index; for
+// example "addresses[].city" allows:
+//
+//	{"addresses": [{"city": "Bristol"}, {"city": "Cardiff"}]}
+//
+// Allowing a key also allows everything below it, unless there are patterns for
+// keys below it. Parent keys are allowed implicitly, so "addresses" doesn't need
+// to be in the list.
+//
+// The errors are added with the full path, e.g. "addresses[1].street".
+func (v *Validator) UnexpectedJSON(m map[string]any, allowed ...string) {
+	allow := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		allow[a] = struct{}{}
+	}
+	v.unexpectedJSON(m, nil, allow)
+}
+
+func (v *Validator) unexpectedJSON(node any, p Path, allow map[string]struct{}) {
+	switch n := node.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(n) {
+			cp := p.join(PathElem{Name: k})
+			pat := pathPattern(cp)
+			_, ok := allow[pat]
+			below := hasBelow(allow, pat)
+			if !ok && !below {
+				v.appendPath(cp, CodeUnexpected, v.msg.Unexpected(), nil)
+				continue
+			}
+			if below {
+				v.unexpectedJSON(n[k], cp, allow)
+			}
+		}
+	case []any:
+		for i, e := range n {
+			v.unexpectedJSON(e, p.join(PathElem{Name: strconv.Itoa(i), Index: true}), allow)
+		}
+	}
+}
+
+// pathPattern gets the path as a string with all indexes removed, e.g.
+// "addresses[].city".
+func pathPattern(p Path) string {
+	pp := make(Path, len(p))
+	for i, e := range p {
+		if e.Index {
+			e.Name = ""
+		}
+		pp[i] = e
+	}
+	return pp.String()
+}
+
+// hasBelow reports if there are any patterns for keys below pat.
+func hasBelow(allow map[string]struct{}, pat string) bool {
+	for a := range allow {
+		if strings.HasPrefix(a, pat+".") || strings.HasPrefix(a, pat+"[]") {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
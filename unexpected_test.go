@@ -0,0 +1,70 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestUnexpected(t *testing.T) {
+	form := url.Values{"a": {"1"}, "b": {"1", "2"}, "c": {"1", "2"}, "x": {""}}
+
+	tests := []struct {
+		val  func(*Validator)
+		want string
+	}{
+		{func(v *Validator) { v.Unexpected(form, "a", "b", "c", "x") }, `null`},
+		{func(v *Validator) { v.Unexpected(form, "a", "b") },
+			`[{"key":"c","code":"unexpected","message":"unknown parameter"},{"key":"x","code":"unexpected","message":"unknown parameter"}]`},
+		{func(v *Validator) { v.Unexpected(form) },
+			`[{"key":"a","code":"unexpected","message":"unknown parameter"},{"key":"b","code":"unexpected","message":"unknown parameter"},{"key":"c","code":"unexpected","message":"unknown parameter"},{"key":"x","code":"unexpected","message":"unknown parameter"}]`},
+		{func(v *Validator) { v.Duplicate(form, "a", "b") },
+			`[{"key":"b","code":"duplicate","message":"cannot be given more than once"}]`},
+		{func(v *Validator) { v.Duplicate(form) },
+			`[{"key":"b","code":"duplicate","message":"cannot be given more than once"},{"key":"c","code":"duplicate","message":"cannot be given more than once"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			v := New()
+			tt.val(&v)
+			if d := ztest.Diff(mustJSON(t, v.FieldErrors()), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+func TestUnexpectedJSON(t *testing.T) {
+	var m map[string]any
+	err := json.Unmarshal([]byte(`{
+		"name":      "x",
+		"admin":     true,
+		"meta":      {"a": 1, "b": [1, 2]},
+		"address":   {"city": "x", "zip": "y"},
+		"items":     [{"name": "a"}, {"name": "b", "price": 1}, "str"],
+		"matrix":    [[{"a": 1, "b": 2}]]
+	}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := New()
+	v.UnexpectedJSON(m, "name", "meta", "address.city", "items[].name", "matrix[][].a")
+
+	want := `
+address.zip: unknown parameter.
+admin: unknown parameter.
+items[1].price: unknown parameter.
+matrix[0][0].b: unknown parameter.`[1:]
+	if d := ztest.Diff(v.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	have := v.FieldErrors()[2].Path.JSONPointer()
+	if d := ztest.Diff(have, "/items/1/price"); d != "" {
+		t.Error(d)
+	}
+}
@@ -3,6 +3,8 @@ package zvalidate
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net"
 	"net/mail"
 	"net/url"
@@ -24,17 +26,17 @@ func (v *Validator) Required(key string, value any, message ...string) {
 	val := reflect.ValueOf(value)
 start:
 	if !val.IsValid() {
-		v.Append(key, msg)
+		v.appendError(key, CodeRequired, msg, nil)
 		return
 	}
 	switch val.Kind() {
 	case reflect.Map:
 		if val.IsNil() || val.Len() == 0 {
-			v.Append(key, msg)
+			v.appendError(key, CodeRequired, msg, nil)
 		}
 	case reflect.Slice:
 		if val.IsNil() || val.Len() == 0 {
-			v.Append(key, msg)
+			v.appendError(key, CodeRequired, msg, nil)
 			return
 		}
 		for i := range val.Len() {
@@ -42,17 +44,17 @@ start:
 				return
 			}
 		}
-		v.Append(key, msg)
+		v.appendError(key, CodeRequired, msg, nil)
 	case reflect.Pointer:
 		if val.IsNil() {
-			v.Append(key, msg)
+			v.appendError(key, CodeRequired, msg, nil)
 			return
 		}
 		val = val.Elem()
 		goto start
 	default:
 		if val.IsZero() {
-			v.Append(key, msg)
+			v.appendError(key, CodeRequired, msg, nil)
 		}
 	}
 }
@@ -65,7 +67,8 @@ func (v *Validator) Exclude(key, value string, exclude []string, message ...stri
 	val := strings.TrimSpace(strings.ToLower(value))
 	for _, e := range exclude {
 		if strings.EqualFold(e, val) {
-			v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.Exclude), e))
+			v.appendError(key, CodeExclude, fmt.Sprintf(v.getMessage(message, v.msg.Exclude), e),
+				map[string]any{"exclude": e})
 			return ""
 		}
 	}
@@ -126,19 +129,23 @@ func (v *Validator) Include(key string, value, include any, message ...string) a
 		all = append(all, e)
 	}
 
-	v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.Include), strings.Join(all, ", ")))
+	v.appendError(key, CodeInclude, fmt.Sprintf(v.getMessage(message, v.msg.Include), strings.Join(all, ", ")),
+		map[string]any{"include": all})
 	return ""
 }
 
 // Range sets the minimum and maximum value of a integer.
 //
-// A maximum of 0 indicates there is no upper limit.
+// A maximum of 0 indicates there is no upper limit. Use Min(), Max(), or
+// Between() for other numeric types or a maximum of 0.
 func (v *Validator) Range(key string, value, min, max int64, message ...string) {
 	if value < min {
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.RangeHigher), min))
+		v.appendError(key, CodeRangeTooLow, fmt.Sprintf(v.getMessage(message, v.msg.RangeHigher), min),
+			map[string]any{"min": min, "max": max})
 	}
 	if max > 0 && value > max {
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.RangeLower), max))
+		v.appendError(key, CodeRangeTooHigh, fmt.Sprintf(v.getMessage(message, v.msg.RangeLower), max),
+			map[string]any{"min": min, "max": max})
 	}
 }
 
@@ -158,7 +165,8 @@ func (v *Validator) Domain(key, value string, message ...string) []string {
 
 	labels, err := validDomain(value, 2)
 	if err != nil {
-		v.Append(key, fmt.Sprintf("%s: %s", v.getMessage(message, v.msg.Domain), err))
+		v.appendError(key, CodeDomain, fmt.Sprintf("%s: %s", v.getMessage(message, v.msg.Domain), err),
+			map[string]any{"reason": err.Error()})
 	}
 	return labels
 }
@@ -176,7 +184,8 @@ func (v *Validator) Hostname(key, value string, message ...string) []string {
 
 	labels, err := validDomain(value, 1)
 	if err != nil {
-		v.Append(key, fmt.Sprintf("%s: %s", v.getMessage(message, v.msg.Hostname), err))
+		v.appendError(key, CodeHostname, fmt.Sprintf("%s: %s", v.getMessage(message, v.msg.Hostname), err),
+			map[string]any{"reason": err.Error()})
 	}
 	return labels
 }
@@ -256,7 +265,7 @@ func (v *Validator) url(key, value string, local bool, message ...string) *url.U
 
 	u, err := url.Parse(value)
 	if err != nil && u == nil {
-		v.Appendf(key, "%s: %s", msg, err)
+		v.appendError(key, CodeURL, fmt.Sprintf("%s: %s", msg, err), map[string]any{"reason": err.Error()})
 		return nil
 	}
 
@@ -269,12 +278,12 @@ func (v *Validator) url(key, value string, local bool, message ...string) *url.U
 	}
 
 	if err != nil {
-		v.Appendf(key, "%s: %s", msg, err)
+		v.appendError(key, CodeURL, fmt.Sprintf("%s: %s", msg, err), map[string]any{"reason": err.Error()})
 		return nil
 	}
 
 	if u.Host == "" {
-		v.Append(key, msg)
+		v.appendError(key, CodeURL, msg, nil)
 		return nil
 	}
 
@@ -285,7 +294,7 @@ func (v *Validator) url(key, value string, local bool, message ...string) *url.U
 
 	_, err = validDomain(host, map[bool]int{true: 1, false: 2}[local])
 	if err != nil {
-		v.Append(key, msg)
+		v.appendError(key, CodeURL, msg, nil)
 		return nil
 	}
 
@@ -301,14 +310,14 @@ func (v *Validator) Email(key, value string, message ...string) mail.Address {
 	msg := v.getMessage(message, v.msg.Email)
 	addr, err := mail.ParseAddress(value)
 	if err != nil {
-		v.Append(key, msg)
+		v.appendError(key, CodeEmail, msg, nil)
 		return mail.Address{}
 	}
 
 	// "foo@domain" is technically valid, but practically never what's intended.
 	_, err = validDomain(addr.Address[strings.LastIndex(addr.Address, "@")+1:], 2)
 	if err != nil {
-		v.Append(key, msg)
+		v.appendError(key, CodeEmail, msg, nil)
 		return mail.Address{}
 	}
 
@@ -323,7 +332,7 @@ func (v *Validator) IPv4(key, value string, message ...string) net.IP {
 
 	ip := net.ParseIP(value)
 	if ip == nil || ip.To4() == nil {
-		v.Append(key, v.getMessage(message, v.msg.IPv4))
+		v.appendError(key, CodeIPv4, v.getMessage(message, v.msg.IPv4), nil)
 	}
 	return ip
 }
@@ -336,7 +345,7 @@ func (v *Validator) IP(key, value string, message ...string) net.IP {
 
 	ip := net.ParseIP(value)
 	if ip == nil {
-		v.Append(key, v.getMessage(message, v.msg.IP))
+		v.appendError(key, CodeIP, v.getMessage(message, v.msg.IP), nil)
 	}
 	return ip
 }
@@ -350,7 +359,7 @@ func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8
 	msg := v.getMessage(message, v.msg.HexColor)
 
 	if value[0] != '#' {
-		v.Append(key, msg)
+		v.appendError(key, CodeHexColor, msg, nil)
 		return 0, 0, 0
 	}
 
@@ -364,7 +373,7 @@ func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8
 
 	n, err := fmt.Sscanf(strings.ToLower(value), "#%x", &rgb)
 	if n != 1 || len(rgb) != 3 || err != nil {
-		v.Append(key, msg)
+		v.appendError(key, CodeHexColor, msg, nil)
 		return 0, 0, 0
 	}
 
@@ -380,7 +389,7 @@ func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8
 // reject it.
 func (v *Validator) UTF8(key, value string, message ...string) {
 	if !validString(value) {
-		v.Append(key, v.getMessage(message, v.msg.UTF8))
+		v.appendError(key, CodeUTF8, v.getMessage(message, v.msg.UTF8), nil)
 	}
 }
 
@@ -405,7 +414,7 @@ func (v *Validator) UTF8(key, value string, message ...string) {
 //	unicode.ASCII_Hex_Digit   0-9A-Fa-f
 func (v *Validator) Contains(key, value string, ranges []*unicode.RangeTable, runes []rune, message ...string) {
 	if !validString(value) {
-		v.Append(key, v.getMessage(message, v.msg.UTF8))
+		v.appendError(key, CodeUTF8, v.getMessage(message, v.msg.UTF8), nil)
 	}
 
 	var invalid []rune
@@ -422,7 +431,8 @@ func (v *Validator) Contains(key, value string, ranges []*unicode.RangeTable, ru
 		for i := range invalid {
 			cannot[i] = fmt.Sprintf("%q", invalid[i])
 		}
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.Contains), strings.Join(cannot, ", ")))
+		v.appendError(key, CodeContains, fmt.Sprintf(v.getMessage(message, v.msg.Contains), strings.Join(cannot, ", ")),
+			map[string]any{"invalid": cannot})
 	}
 }
 
@@ -457,9 +467,11 @@ func (v *Validator) Len(key, value string, min, max int, message ...string) int
 	l := utf8.RuneCountInString(value)
 	switch {
 	case l < min:
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.LenLonger), min))
+		v.appendError(key, CodeLenTooShort, fmt.Sprintf(v.getMessage(message, v.msg.LenLonger), min),
+			map[string]any{"min": min, "max": max})
 	case max > 0 && l > max:
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.LenShorter), max))
+		v.appendError(key, CodeLenTooLong, fmt.Sprintf(v.getMessage(message, v.msg.LenShorter), max),
+			map[string]any{"min": min, "max": max})
 	}
 	return l
 }
@@ -472,7 +484,7 @@ func (v *Validator) Integer(key, value string, message ...string) int64 {
 
 	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
 	if err != nil {
-		v.Append(key, v.getMessage(message, v.msg.Integer))
+		v.appendError(key, CodeInteger, v.getMessage(message, v.msg.Integer), nil)
 	}
 	return i
 }
@@ -487,7 +499,7 @@ func (v *Validator) Hex(key, value string, message ...string) int64 {
 	value = strings.TrimPrefix(value, "0X")
 	i, err := strconv.ParseInt(strings.TrimSpace(value), 16, 64)
 	if err != nil {
-		v.Append(key, v.getMessage(message, v.msg.Hex))
+		v.appendError(key, CodeHex, v.getMessage(message, v.msg.Hex), nil)
 	}
 	return i
 }
@@ -502,11 +514,146 @@ func (v *Validator) Octal(key, value string, message ...string) int64 {
 	value = strings.TrimPrefix(value, "0O")
 	i, err := strconv.ParseInt(strings.TrimSpace(value), 8, 64)
 	if err != nil {
-		v.Append(key, v.getMessage(message, v.msg.Octal))
+		v.appendError(key, CodeOctal, v.getMessage(message, v.msg.Octal), nil)
 	}
 	return i
 }
 
+// Float parses a string as a floating-point number, such as "12.50" or "1e3".
+//
+// NaN and infinity are not valid; use FloatSpecial() to allow them.
+func (v *Validator) Float(key, value string, message ...string) float64 {
+	f := v.FloatSpecial(key, value, message...)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		v.appendError(key, CodeFloat, v.getMessage(message, v.msg.Float), nil)
+		return 0
+	}
+	return f
+}
+
+// FloatSpecial is like Float(), but also allows "NaN", "Inf", "+Inf", and
+// "-Inf".
+func (v *Validator) FloatSpecial(key, value string, message ...string) float64 {
+	if value == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		v.appendError(key, CodeFloat, v.getMessage(message, v.msg.Float), nil)
+		return 0
+	}
+	return f
+}
+
+// Decimal parses a string as a fixed-point decimal number, such as "12.50".
+//
+// This is intended for values that must be exact, such as money: the value is
+// never converted to a float.
+//
+// The number may have at most intDigits digits before the decimal point and
+// fracDigits after it, not counting leading zeros before and trailing zeros
+// after the decimal point. An intDigits of 0 or lower and fracDigits lower than
+// 0 indicate there is no limit; fracDigits of 0 allows only whole numbers.
+//
+// Exponents ("1e3") and thousands separators ("1,000") are not accepted.
+func (v *Validator) Decimal(key, value string, intDigits, fracDigits int, message ...string) *big.Rat {
+	if value == "" {
+		return nil
+	}
+
+	neg, ip, fp, ok := v.decimal(key, value, intDigits, fracDigits, message...)
+	if !ok {
+		return nil
+	}
+	r, _ := new(big.Rat).SetString(decimalString(neg, ip, fp))
+	return r
+}
+
+// DecimalUnits is like Decimal(), but returns the value as an integer in the
+// minor units (e.g. cents), which is the value multiplied by 10^fracDigits.
+//
+// For example "12.5" with fracDigits of 2 is 1250. It will panic if fracDigits
+// is lower than 0.
+//
+// Values that don't fit in an int64 are a CodeRangeTooHigh or CodeRangeTooLow
+// error, with the bound as a string in the "max" or "min" param.
+func (v *Validator) DecimalUnits(key, value string, intDigits, fracDigits int, message ...string) int64 {
+	if fracDigits < 0 {
+		panic("zvalidate.DecimalUnits: fracDigits must be 0 or higher")
+	}
+	if value == "" {
+		return 0
+	}
+
+	neg, ip, fp, ok := v.decimal(key, value, intDigits, fracDigits, message...)
+	if !ok {
+		return 0
+	}
+	i, _ := new(big.Int).SetString(decimalString(neg, ip+fp+strings.Repeat("0", fracDigits-len(fp)), ""), 10)
+	if !i.IsInt64() {
+		// The bound in the value's units (e.g. 92233720368547758.07 for
+		// fracDigits=2), as a string as it doesn't fit in a float64.
+		bound, div := big.NewInt(math.MaxInt64), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fracDigits)), nil)
+		if neg {
+			bound = big.NewInt(math.MinInt64)
+		}
+		b := new(big.Rat).SetFrac(bound, div).FloatString(fracDigits)
+		if neg {
+			v.appendError(key, CodeRangeTooLow, fmt.Sprintf(v.getMessage(message, v.msg.NumberHigher), b),
+				map[string]any{"min": b})
+		} else {
+			v.appendError(key, CodeRangeTooHigh, fmt.Sprintf(v.getMessage(message, v.msg.NumberLower), b),
+				map[string]any{"max": b})
+		}
+		return 0
+	}
+	return i.Int64()
+}
+
+// decimal parses and validates a decimal, returning the digits before and after
+// the decimal point without leading and trailing zeros.
+func (v *Validator) decimal(key, value string, intDigits, fracDigits int, message ...string) (bool, string, string, bool) {
+	s := strings.TrimSpace(value)
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	ip, fp, _ := strings.Cut(s, ".")
+	if ip == "" && fp == "" || strings.Trim(ip, "0123456789") != "" || strings.Trim(fp, "0123456789") != "" {
+		v.appendError(key, CodeDecimal, v.getMessage(message, v.msg.Decimal), nil)
+		return false, "", "", false
+	}
+
+	ip, fp = strings.TrimLeft(ip, "0"), strings.TrimRight(fp, "0")
+	params := map[string]any{"int_digits": intDigits, "frac_digits": fracDigits}
+	switch {
+	case intDigits > 0 && len(ip) > intDigits:
+		v.appendError(key, CodeDecimalInt, fmt.Sprintf(v.getMessage(message, v.msg.DecimalInt), intDigits), params)
+		return false, "", "", false
+	case fracDigits == 0 && fp != "":
+		v.appendError(key, CodeDecimalFrac, v.getMessage(message, v.msg.Integer), params)
+		return false, "", "", false
+	case fracDigits > 0 && len(fp) > fracDigits:
+		v.appendError(key, CodeDecimalFrac, fmt.Sprintf(v.getMessage(message, v.msg.DecimalFrac), fracDigits), params)
+		return false, "", "", false
+	}
+	return neg, ip, fp, true
+}
+
+func decimalString(neg bool, ip, fp string) string {
+	if ip == "" {
+		ip = "0"
+	}
+	if fp != "" {
+		ip += "." + fp
+	}
+	if neg {
+		return "-" + ip
+	}
+	return ip
+}
+
 // Boolean parses as string as a boolean.
 func (v *Validator) Boolean(key, value string, message ...string) bool {
 	if value == "" {
@@ -519,7 +666,7 @@ func (v *Validator) Boolean(key, value string, message ...string) bool {
 	case "0", "n", "no", "f", "false", "off":
 		return false
 	}
-	v.Append(key, v.getMessage(message, v.msg.Bool))
+	v.appendError(key, CodeBool, v.getMessage(message, v.msg.Bool), nil)
 	return false
 }
 
@@ -531,7 +678,8 @@ func (v *Validator) Date(key, value, layout string, message ...string) time.Time
 
 	t, err := time.Parse(layout, value)
 	if err != nil {
-		v.Append(key, fmt.Sprintf(v.getMessage(message, v.msg.Date), layout))
+		v.appendError(key, CodeDate, fmt.Sprintf(v.getMessage(message, v.msg.Date), layout),
+			map[string]any{"layout": layout})
 	}
 	return t
 }
@@ -560,7 +708,7 @@ func phone(v string) (string, bool) {
 func (v *Validator) Phone(key, value string, message ...string) string {
 	clean, ok := phone(value)
 	if !ok {
-		v.Append(key, v.getMessage(message, v.msg.Phone))
+		v.appendError(key, CodePhone, v.getMessage(message, v.msg.Phone), nil)
 	}
 	return clean
 }
@@ -575,7 +723,7 @@ func (v *Validator) Phone(key, value string, message ...string) string {
 func (v *Validator) PhoneInternational(key, value string, message ...string) string {
 	clean, ok := phone(value)
 	if !ok || (clean != "" && clean[0] != '+') {
-		v.Append(key, v.getMessage(message, v.msg.PhoneInternational))
+		v.appendError(key, CodePhoneInternational, v.getMessage(message, v.msg.PhoneInternational), nil)
 	}
 	return clean
 }
@@ -2,6 +2,7 @@ package zvalidate
 
 import (
 	"fmt"
+	"math"
 	"net/mail"
 	"reflect"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"testing"
 	"time"
 	"unicode"
+
+	"zgo.at/zvalidate/internal/ztest"
 )
 
 func TestRequiredInt(t *testing.T) {
@@ -874,6 +877,126 @@ func TestInteger(t *testing.T) {
 	}
 }
 
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		val        func(Validator) float64
+		want       string
+		wantErrors map[string][]string
+	}{
+		{func(v Validator) float64 { return v.Float("k", "") }, "0", map[string][]string{}},
+		{func(v Validator) float64 { return v.Float("k", "12.50") }, "12.5", map[string][]string{}},
+		{func(v Validator) float64 { return v.Float("k", " -1e3 ") }, "-1000", map[string][]string{}},
+		{func(v Validator) float64 { return v.Float("k", ".5") }, "0.5", map[string][]string{}},
+		{func(v Validator) float64 { return v.Float("k", "x") }, "0", map[string][]string{"k": {"must be a number"}}},
+		{func(v Validator) float64 { return v.Float("k", "1,5") }, "0", map[string][]string{"k": {"must be a number"}}},
+		{func(v Validator) float64 { return v.Float("k", "1e400") }, "0", map[string][]string{"k": {"must be a number"}}},
+		{func(v Validator) float64 { return v.Float("k", "NaN") }, "0", map[string][]string{"k": {"must be a number"}}},
+		{func(v Validator) float64 { return v.Float("k", "-Inf") }, "0", map[string][]string{"k": {"must be a number"}}},
+		{func(v Validator) float64 { return v.FloatSpecial("k", "NaN") }, "NaN", map[string][]string{}},
+		{func(v Validator) float64 { return v.FloatSpecial("k", "-Inf") }, "-Inf", map[string][]string{}},
+		{func(v Validator) float64 { return v.FloatSpecial("k", "x") }, "0", map[string][]string{"k": {"must be a number"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			f := tt.val(v)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if have := fmt.Sprint(f); have != tt.want {
+				t.Errorf("\nout:  %s\nwant: %s\n", have, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		in         string
+		intDigits  int
+		fracDigits int
+		want       string
+		wantUnits  int64
+		wantErrors map[string][]string
+	}{
+		{"", 5, 2, "<nil>", 0, map[string][]string{}},
+		{"12.50", 5, 2, "25/2", 1250, map[string][]string{}},
+		{" -12.5 ", 5, 2, "-25/2", -1250, map[string][]string{}},
+		{"+0.01", 5, 2, "1/100", 1, map[string][]string{}},
+		{".5", 5, 2, "1/2", 50, map[string][]string{}},
+		{"12.", 5, 2, "12/1", 1200, map[string][]string{}},
+		{"00012.500", 2, 2, "25/2", 1250, map[string][]string{}},
+		{"12345", 5, 0, "12345/1", 12345, map[string][]string{}},
+		{"123456789.123456789", 0, -1, "123456789123456789/1000000000", 0, map[string][]string{}},
+		{"-0", 5, 2, "0/1", 0, map[string][]string{}},
+
+		{"123456", 5, 2, "<nil>", 0, map[string][]string{"k": {"must have at most 5 digits before the decimal point"}}},
+		{"1.234", 5, 2, "<nil>", 0, map[string][]string{"k": {"must have at most 2 decimal places"}}},
+		{"1.5", 5, 0, "<nil>", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1e3", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+		{"1,000", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+		{"1.2.3", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+		{"-", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+		{".", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+		{"--1", 5, 2, "<nil>", 0, map[string][]string{"k": {"must be a decimal number"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			r := v.Decimal("k", tt.in, tt.intDigits, tt.fracDigits)
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if have := fmt.Sprint(r); have != tt.want {
+				t.Errorf("\nout:  %s\nwant: %s\n", have, tt.want)
+			}
+
+			if tt.fracDigits >= 0 {
+				v := New()
+				u := v.DecimalUnits("k", tt.in, tt.intDigits, tt.fracDigits)
+				if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+					t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+				}
+				if u != tt.wantUnits {
+					t.Errorf("\nout:  %d\nwant: %d\n", u, tt.wantUnits)
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalUnitsOverflow(t *testing.T) {
+	tests := []struct {
+		in, want string
+		frac     int
+	}{
+		{"99999999999999999999", `[{"key":"k","code":"range_too_high","params":{"max":"9223372036854775807"},"message":"must be 9223372036854775807 or lower"}]`, 0},
+		{"99999999999999999999", `[{"key":"k","code":"range_too_high","params":{"max":"92233720368547758.07"},"message":"must be 92233720368547758.07 or lower"}]`, 2},
+		{"-99999999999999999999", `[{"key":"k","code":"range_too_low","params":{"min":"-92233720368547758.08"},"message":"must be -92233720368547758.08 or higher"}]`, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			if u := v.DecimalUnits("k", tt.in, 0, tt.frac); u != 0 {
+				t.Errorf("value: %d", u)
+			}
+			if d := ztest.Diff(mustJSON(t, v.FieldErrors()), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	// Bounds are still valid.
+	v := New()
+	if u := v.DecimalUnits("k", "-92233720368547758.08", 0, 2); u != math.MinInt64 || v.HasErrors() {
+		t.Errorf("%d %v", u, v.Errors)
+	}
+}
+
 func TestBoolean(t *testing.T) {
 	tests := []struct {
 		val        func(Validator) bool
@@ -0,0 +1,60 @@
+package zvalidate
+
+import "maps"
+
+// Go runs f in a new goroutine.
+//
+// f gets a new Validator with the same Messages as v, rather than v itself, so
+// there is no need for any locking. The errors are merged in to v when Wait()
+// is called.
+//
+// This is useful for running slow checks (database queries, DNS lookups, etc.)
+// in parallel:
+//
+//	v := zvalidate.New()
+//	v.Required("email", c.Email)
+//	v.Go(func(v *zvalidate.Validator) {
+//	    if !c.isUniqueEmail(c.Email) {
+//	        v.Append("email", "must be unique")
+//	    }
+//	})
+//	v.Go(func(v *zvalidate.Validator) {
+//	    if _, err := net.LookupMX(c.Domain); err != nil {
+//	        v.Append("domain", "must have an MX record")
+//	    }
+//	})
+//	v.Wait()
+func (v *Validator) Go(f func(*Validator)) {
+	sub := New()
+	sub.msg = v.msg
+
+	st := v.st()
+	sub.state.bail, sub.state.bailKeys = st.bail, maps.Clone(st.bailKeys)
+	st.mu.Lock()
+	st.pending = append(st.pending, &sub)
+	st.mu.Unlock()
+
+	st.wg.Add(1)
+	go func() {
+		defer st.wg.Done()
+		f(&sub)
+	}()
+}
+
+// Wait for all functions started with Go() to finish.
+//
+// The errors are merged in the order Go() was called, rather than in the order
+// the functions finished, so the result is always the same.
+func (v *Validator) Wait() {
+	st := v.st()
+	st.wg.Wait()
+
+	st.mu.Lock()
+	pending := st.pending
+	st.pending = nil
+	st.mu.Unlock()
+
+	for _, p := range pending {
+		v.Merge(*p)
+	}
+}
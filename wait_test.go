@@ -0,0 +1,87 @@
+package zvalidate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestGo(t *testing.T) {
+	v := New()
+	v.Ordered(true)
+	v.Required("name", "")
+
+	for i := range 10 {
+		v.Go(func(v *Validator) {
+			// Make sure the first ones finish last.
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			v.Appendf("slow", "err %d", i)
+			v.Integer(fmt.Sprintf("int%d", i), "x")
+		})
+	}
+	v.Go(func(v *Validator) {})
+	v.Wait()
+
+	want := "name: must be set.\n" +
+		"slow: err 0, err 1, err 2, err 3, err 4, err 5, err 6, err 7, err 8, err 9.\n" +
+		"int0: must be a whole number.\n" +
+		"int1: must be a whole number.\n" +
+		"int2: must be a whole number.\n" +
+		"int3: must be a whole number.\n" +
+		"int4: must be a whole number.\n" +
+		"int5: must be a whole number.\n" +
+		"int6: must be a whole number.\n" +
+		"int7: must be a whole number.\n" +
+		"int8: must be a whole number.\n" +
+		"int9: must be a whole number.\n"
+	if d := ztest.Diff(v.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	{ // Wait again shouldn't do anything.
+		v.Wait()
+		if d := ztest.Diff(v.String(), want); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestGoMessages(t *testing.T) {
+	v := New()
+	v.Messages(Messages{Required: func() string { return "X" }})
+	v.Go(func(v *Validator) { v.Required("k", "") })
+	v.Wait()
+
+	if d := ztest.Diff(v.String(), "k: X."); d != "" {
+		t.Error(d)
+	}
+}
+
+// Bail() after Go() shouldn't race with the goroutines; run with -race.
+func TestGoBail(t *testing.T) {
+	v := New()
+	v.Bail("a")
+
+	start := make(chan struct{})
+	v.Go(func(v *Validator) {
+		<-start
+		for range 100 {
+			v.Required("a", "")
+			v.Required("b", "")
+		}
+	})
+	close(start)
+	v.Bail("b")
+	for range 100 {
+		v.Required("b", "")
+	}
+	v.Wait()
+
+	// "b" in the goroutine isn't bailed as Bail() was called after Go(), but
+	// the errors aren't merged as "b" in v already has an error.
+	if d := ztest.Diff(v.String(), "a: must be set.\nb: must be set."); d != "" {
+		t.Error(d)
+	}
+}
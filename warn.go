@@ -0,0 +1,54 @@
+package zvalidate
+
+// Warnings gets a Validator to add warnings to.
+//
+// Warnings are displayed to the user, but are not errors: HasErrors() and
+// ErrorOrNil() ignore them. String(), HTML(), TemplateError(), and the JSON
+// output include them after the errors.
+//
+// All validators can be used to add a warning rather than an error:
+//
+//	v.Warnings().Len("phone", phone, 8, 0, "should be at least %d digits")
+//
+// This always returns the same Validator; calling Warnings() on that returns
+// itself.
+func (v *Validator) Warnings() *Validator {
+	st := v.st()
+	if st.warn {
+		return v
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.warnings == nil {
+		w := New()
+		w.msg = v.msg
+		w.state.warn = true
+		w.state.ordered = st.ordered
+		st.warnings = &w
+	}
+	return st.warnings
+}
+
+// Warn adds a new warning.
+//
+// This is the same as v.Warnings().Append(key, msg).
+func (v *Validator) Warn(key, msg string) {
+	v.Warnings().Append(key, msg)
+}
+
+// HasWarnings reports if there are any warnings.
+func (v *Validator) HasWarnings() bool {
+	w := v.warnings()
+	return w != nil && w.HasErrors()
+}
+
+// warnings gets the warnings Validator, or nil if there isn't one.
+func (v *Validator) warnings() *Validator {
+	if v.state == nil || v.state.warn {
+		return nil
+	}
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+	return v.state.warnings
+}
@@ -0,0 +1,107 @@
+package zvalidate
+
+import (
+	"html/template"
+	"testing"
+
+	"zgo.at/zvalidate/internal/ztest"
+)
+
+func TestWarnings(t *testing.T) {
+	v := New()
+	if v.HasWarnings() {
+		t.Fatal("HasWarnings() is true")
+	}
+
+	v.Warn("url", "has no https scheme")
+	v.Warnings().Len("phone", "123", 8, 0, "should be at least %d digits")
+
+	if v.HasErrors() {
+		t.Error("HasErrors() is true")
+	}
+	if v.ErrorOrNil() != nil {
+		t.Error("ErrorOrNil() is not nil")
+	}
+	if !v.HasWarnings() {
+		t.Error("HasWarnings() is false")
+	}
+	if v.Warnings() != v.Warnings().Warnings() {
+		t.Error("Warnings().Warnings() is a different Validator")
+	}
+
+	{
+		have := v.String()
+		want := "phone: warning: should be at least 8 digits.\nurl: warning: has no https scheme.\n"
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	v.Required("name", "")
+
+	{
+		have := v.String()
+		want := "name: must be set.\nphone: warning: should be at least 8 digits.\nurl: warning: has no https scheme.\n"
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		have := string(v.HTML())
+		want := "<ul class='zvalidate'>\n" +
+			"<li><strong>name</strong>: must be set.</li>\n" +
+			"<li class='warning'><strong>phone</strong>: Warning: should be at least 8 digits.</li>\n" +
+			"<li class='warning'><strong>url</strong>: Warning: has no https scheme.</li>\n" +
+			"</ul>\n"
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		have := mustJSON(t, v)
+		want := `{"errors":{"name":["must be set"]},"warnings":{"phone":["should be at least 8 digits"],"url":["has no https scheme"]}}`
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		have := mustJSON(t, v.Warnings().FieldErrors())
+		want := `[{"key":"phone","code":"len_too_short","params":{"max":0,"min":8},"message":"should be at least 8 digits"},{"key":"url","message":"has no https scheme"}]`
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		have := TemplateError("url", &v) + TemplateError("name", &v)
+		want := template.HTML(`<span class="warn">Warning: has no https scheme</span>` +
+			`<span class="err" id="err-name">Error: must be set</span>`)
+		if d := ztest.Diff(string(have), string(want)); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestWarningsSub(t *testing.T) {
+	v := New()
+
+	s := New()
+	s.Warn("city", "looks odd")
+	s.Required("zip", "")
+	v.Sub("addresses", "0", s)
+
+	m := New()
+	m.Warn("other", "merged")
+	v.Merge(m)
+
+	v.Go(func(v *Validator) { v.Warn("go", "from goroutine") })
+	v.Wait()
+
+	have := v.String()
+	want := "addresses[0].zip: must be set.\n" +
+		"addresses[0].city: warning: looks odd.\n" +
+		"go: warning: from goroutine.\n" +
+		"other: warning: merged.\n"
+	if d := ztest.Diff(have, want); d != "" {
+		t.Error(d)
+	}
+}
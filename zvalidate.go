@@ -4,12 +4,14 @@
 package zvalidate
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"sort"
 	"strings"
+	"sync"
 )
 
 // Validator hold the validation errors.
@@ -18,11 +20,109 @@ import (
 type Validator struct {
 	Errors map[string][]string `json:"errors"`
 	msg    Messages
+	tpl    Templates
+
+	details map[string][]FieldError
+	state   *state
+}
+
+// state is shared between copies of a Validator.
+type state struct {
+	keys    []string // Keys in the order they were first added.
+	ordered bool
+
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	pending  []*Validator // Started with Go(), merged on Wait().
+	internal []error      // Errors from Check().
+	causes   []error      // Non-Validator errors from Sub().
+
+	warnings *Validator // Set for the Validator that has warnings.
+	warn     bool       // Set on the warnings Validator itself.
+
+	bail     bool                // Bail() for all keys.
+	bailKeys map[string]struct{} // Bail() for specific keys.
+
+	displayed map[string]struct{} // Keys that were passed to Peek().
+}
+
+func (v *Validator) st() *state {
+	if v.state == nil {
+		v.state = new(state)
+	}
+	return v.state
 }
 
 // New initializes a new Validator.
 func New() Validator {
-	return Validator{Errors: make(map[string][]string), msg: DefaultMessages}
+	return Validator{
+		Errors:  make(map[string][]string),
+		msg:     DefaultMessages,
+		details: make(map[string][]FieldError),
+		state:   new(state),
+	}
+}
+
+// Ordered sets if String(), HTML(), and the JSON output list the errors in the
+// order they were first added, rather than sorted by key.
+//
+// This is useful for forms, where you want the errors to appear in the same
+// order as the fields.
+func (v *Validator) Ordered(ordered bool) {
+	v.st().ordered = ordered
+	if w := v.warnings(); w != nil {
+		w.st().ordered = ordered
+	}
+}
+
+// Keys gets all keys with errors in the order they were first added.
+//
+// Keys that were added to the Errors map directly are listed last, sorted.
+func (v *Validator) Keys() []string {
+	if !v.HasErrors() {
+		return nil
+	}
+
+	keys := make([]string, 0, len(v.Errors))
+	seen := make(map[string]struct{}, len(v.Errors))
+	if v.state != nil {
+		for _, k := range v.state.keys {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			if _, ok := v.Errors[k]; ok {
+				keys = append(keys, k)
+				seen[k] = struct{}{}
+			}
+		}
+	}
+	if len(keys) == len(v.Errors) {
+		return keys
+	}
+
+	rest := make([]string, 0, len(v.Errors)-len(keys))
+	for k := range v.Errors {
+		if _, ok := seen[k]; !ok {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// keys gets all keys with errors, either sorted or in the order they were
+// added if Ordered() is set.
+func (v Validator) keys() []string {
+	if v.state != nil && v.state.ordered {
+		return v.Keys()
+	}
+
+	keys := make([]string, 0, len(v.Errors))
+	for k := range v.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Messages sets the messages to use for validation errors.
@@ -66,6 +166,12 @@ func (v *Validator) Messages(m Messages) {
 	if m.Integer == nil {
 		m.Integer = DefaultMessages.Integer
 	}
+	if m.Hex == nil {
+		m.Hex = DefaultMessages.Hex
+	}
+	if m.Octal == nil {
+		m.Octal = DefaultMessages.Octal
+	}
 	if m.Bool == nil {
 		m.Bool = DefaultMessages.Bool
 	}
@@ -75,6 +181,9 @@ func (v *Validator) Messages(m Messages) {
 	if m.Phone == nil {
 		m.Phone = DefaultMessages.Phone
 	}
+	if m.PhoneInternational == nil {
+		m.PhoneInternational = DefaultMessages.PhoneInternational
+	}
 	if m.RangeHigher == nil {
 		m.RangeHigher = DefaultMessages.RangeHigher
 	}
@@ -87,7 +196,55 @@ func (v *Validator) Messages(m Messages) {
 	if m.Contains == nil {
 		m.Contains = DefaultMessages.Contains
 	}
+	if m.Unexpected == nil {
+		m.Unexpected = DefaultMessages.Unexpected
+	}
+	if m.Duplicate == nil {
+		m.Duplicate = DefaultMessages.Duplicate
+	}
+	if m.JSONType == nil {
+		m.JSONType = DefaultMessages.JSONType
+	}
+	if m.JSONSyntax == nil {
+		m.JSONSyntax = DefaultMessages.JSONSyntax
+	}
+	if m.NumberHigher == nil {
+		m.NumberHigher = DefaultMessages.NumberHigher
+	}
+	if m.NumberHigherThan == nil {
+		m.NumberHigherThan = DefaultMessages.NumberHigherThan
+	}
+	if m.NumberLower == nil {
+		m.NumberLower = DefaultMessages.NumberLower
+	}
+	if m.NumberLowerThan == nil {
+		m.NumberLowerThan = DefaultMessages.NumberLowerThan
+	}
+	if m.NumberBetween == nil {
+		m.NumberBetween = DefaultMessages.NumberBetween
+	}
+	if m.MultipleOf == nil {
+		m.MultipleOf = DefaultMessages.MultipleOf
+	}
+	if m.Float == nil {
+		m.Float = DefaultMessages.Float
+	}
+	if m.Decimal == nil {
+		m.Decimal = DefaultMessages.Decimal
+	}
+	if m.DecimalInt == nil {
+		m.DecimalInt = DefaultMessages.DecimalInt
+	}
+	if m.DecimalFrac == nil {
+		m.DecimalFrac = DefaultMessages.DecimalFrac
+	}
+	if m.Cause == nil {
+		m.Cause = DefaultMessages.Cause
+	}
 	v.msg = m
+	if w := v.warnings(); w != nil {
+		w.msg = m
+	}
 }
 
 // As tries to convert this error to a Validator, returning nil if it's not.
@@ -100,23 +257,110 @@ func As(err error) *Validator {
 }
 
 // Error interface.
-func (v Validator) Error() string { return v.String() }
+//
+// This doesn't include the text of internal errors from Check(); if there are
+// only internal errors then this is a generic message.
+func (v Validator) Error() string {
+	if !v.HasErrors() && v.InternalError() != nil {
+		return "zvalidate: internal error"
+	}
+	return v.String()
+}
+
+// Unwrap gets all errors that were passed to Sub() that weren't a Validator, as
+// well as any internal errors from Check().
+func (v Validator) Unwrap() []error {
+	if v.state == nil {
+		return nil
+	}
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+	if len(v.state.causes) == 0 && len(v.state.internal) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(v.state.causes)+len(v.state.internal))
+	errs = append(errs, v.state.causes...)
+	return append(errs, v.state.internal...)
+}
 
 // Code returns the HTTP status code for the error. Satisfies the guru.coder
 // interface in zgo.at/guru.
-func (v Validator) Code() int { return 400 }
+//
+// This is 500 if a Check() failed with an internal error, and 400 otherwise.
+func (v Validator) Code() int {
+	if v.InternalError() != nil {
+		return 500
+	}
+	return 400
+}
 
 // ErrorJSON for reporting errors as JSON.
 func (v Validator) ErrorJSON() ([]byte, error) { return json.Marshal(v) }
 
+// MarshalJSON writes the errors as {"errors": {..}}, keeping the order if
+// Ordered() is set. Warnings are added as "warnings", if there are any.
+func (v Validator) MarshalJSON() ([]byte, error) {
+	if v.Errors == nil && !v.HasWarnings() {
+		return []byte(`{"errors":null}`), nil
+	}
+
+	b := new(bytes.Buffer)
+	b.WriteString(`{"errors":`)
+	err := writeJSON(b, v.Errors, v.keys())
+	if err != nil {
+		return nil, err
+	}
+	if w := v.warnings(); w != nil && w.HasErrors() {
+		b.WriteString(`,"warnings":`)
+		err := writeJSON(b, w.Errors, w.keys())
+		if err != nil {
+			return nil, err
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func writeJSON(b *bytes.Buffer, errs map[string][]string, keys []string) error {
+	if errs == nil {
+		b.WriteString("null")
+		return nil
+	}
+
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		kj, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		mj, err := json.Marshal(errs[k])
+		if err != nil {
+			return err
+		}
+		b.Write(kj)
+		b.WriteByte(':')
+		b.Write(mj)
+	}
+	b.WriteByte('}')
+	return nil
+}
+
 // Append a new error.
 func (v *Validator) Append(key, msg string) {
-	v.Errors[key] = append(v.Errors[key], msg)
+	v.appendError(key, "", msg, nil)
 }
 
 // Appendf appends a new error.
 func (v *Validator) Appendf(key, msg string, format ...any) {
-	v.Errors[key] = append(v.Errors[key], fmt.Sprintf(msg, format...))
+	v.appendError(key, "", fmt.Sprintf(msg, format...), nil)
+}
+
+// AppendPath appends a new error for the path.
+func (v *Validator) AppendPath(p Path, msg string) {
+	v.appendPath(p, "", msg, nil)
 }
 
 // Pop an error, removing all errors for this key.
@@ -133,9 +377,54 @@ func (v *Validator) Pop(key string) []string {
 
 	errs := v.Errors[key]
 	delete(v.Errors, key)
+	delete(v.details, key)
+	if v.state != nil {
+		delete(v.state.displayed, key)
+		for i, k := range v.state.keys {
+			if k == key {
+				v.state.keys = append(v.state.keys[:i:i], v.state.keys[i+1:]...)
+				break
+			}
+		}
+	}
 	return errs
 }
 
+// Peek gets the errors for a key and marks them as displayed, without removing
+// them.
+//
+// This is like Pop(), except that the errors can be displayed more than once
+// (e.g. in a summary and next to the input). Use Remaining() to get the keys
+// that weren't displayed.
+//
+// Returns nil if there are no errors for this key.
+func (v *Validator) Peek(key string) []string {
+	st := v.st()
+	if st.displayed == nil {
+		st.displayed = make(map[string]struct{})
+	}
+	st.displayed[key] = struct{}{}
+
+	if len(v.Errors[key]) == 0 {
+		return nil
+	}
+	return v.Errors[key]
+}
+
+// Remaining gets all keys with errors that weren't displayed with Peek().
+func (v *Validator) Remaining() []string {
+	var rem []string
+	for _, k := range v.keys() {
+		if v.state != nil {
+			if _, ok := v.state.displayed[k]; ok {
+				continue
+			}
+		}
+		rem = append(rem, k)
+	}
+	return rem
+}
+
 // HasErrors reports if this validation has any errors.
 func (v *Validator) HasErrors() bool {
 	return len(v.Errors) > 0
@@ -154,8 +443,12 @@ func (v *Validator) HasErrors() bool {
 // Can now be:
 //
 //	return v.ErrorOrNil()
+//
+// The Validator is also returned if a Check() failed with an internal error;
+// use InternalError() or errors.Is() to get it. The text of internal errors is
+// never included in the messages, so it's safe to pass to Sub().
 func (v *Validator) ErrorOrNil() error {
-	if v.HasErrors() {
+	if v.HasErrors() || v.InternalError() != nil {
 		return v
 	}
 	return nil
@@ -164,10 +457,15 @@ func (v *Validator) ErrorOrNil() error {
 // Sub adds sub-validations.
 //
 // Errors from the subvalidation are merged with the top-level one, the keys are
-// added as "top.sub" or "top[n].sub".
+// added as "top.sub" or "top[n].sub". Errors without a key in the
+// subvalidation are added as just "top" or "top[n]"; previous versions added
+// these as "top." with a trailing dot.
 //
 // If the error is not a Validator the text will be added as just the key name
-// without subkey (i.e. the same as v.Append("key", "msg")).
+// without subkey (i.e. the same as v.Append("key", "msg")). The error itself is
+// retained, and can be accessed with errors.Is() and errors.As(). Set the Cause
+// message to show a different message to users, for example to avoid
+// displaying database errors.
 //
 // For example:
 //
@@ -189,95 +487,144 @@ func (v *Validator) ErrorOrNil() error {
 //	    v.Sub("addresses", i, addr.Validate())
 //	}
 func (v *Validator) Sub(key, subKey string, err error) {
-	if err == nil {
-		return
+	p := Path{{Name: key}}
+	if subKey != "" {
+		p = append(p, PathElem{Name: subKey, Index: true})
 	}
+	v.SubPath(p, err)
+}
 
-	if subKey != "" {
-		key = fmt.Sprintf("%s[%s]", key, subKey)
+// SubPath is like Sub(), but with a Path to add the errors to.
+func (v *Validator) SubPath(p Path, err error) {
+	if err == nil {
+		return
 	}
 
 	sub, ok := err.(*Validator)
 	if !ok {
 		ss, ok := err.(Validator)
 		if !ok {
-			v.Append(key, err.Error())
+			msg := err.Error()
+			if v.msg.Cause != nil {
+				msg = v.msg.Cause()
+			}
+			v.appendPath(p, "", msg, nil)
+			v.addCause(err)
 			return
 		}
 		sub = &ss
 	}
+	v.mergeCauses(sub)
+	if w := sub.warnings(); w != nil {
+		v.Warnings().SubPath(p, w)
+	}
 	if !sub.HasErrors() {
 		return
 	}
 
-	for k, val := range sub.Errors {
-		mk := fmt.Sprintf("%s.%s", key, k)
-		v.Errors[mk] = append(v.Errors[mk], val...)
+	for _, k := range sub.Keys() {
+		v.mergeKey(p, sub, k)
 	}
 }
 
+// at runs f with a new Validator, adding the errors below path p.
+func (v *Validator) at(p Path, f func(*Validator)) {
+	sub := New()
+	sub.msg = v.msg
+	f(&sub)
+	v.SubPath(p, &sub)
+}
+
 // Merge errors from another validator in to this one.
 func (v *Validator) Merge(other Validator) {
-	for k, val := range other.Errors {
-		v.Errors[k] = append(v.Errors[k], val...)
+	for _, k := range other.Keys() {
+		v.mergeKey(nil, &other, k)
+	}
+	v.mergeCauses(&other)
+	if w := other.warnings(); w != nil {
+		v.Warnings().Merge(*w)
+	}
+}
+
+// mergeCauses adds the causes and internal errors from other.
+func (v *Validator) mergeCauses(other *Validator) {
+	if other.state == nil || other.state == v.state {
+		return
+	}
+	other.state.mu.Lock()
+	causes, internal := other.state.causes, other.state.internal
+	other.state.mu.Unlock()
+
+	for _, err := range causes {
+		v.addCause(err)
+	}
+	for _, err := range internal {
+		v.addInternal(err)
+	}
+}
+
+func (v *Validator) addCause(err error) {
+	st := v.st()
+	st.mu.Lock()
+	st.causes = append(st.causes, err)
+	st.mu.Unlock()
+}
+
+// mergeKey appends all errors for the key k in other, prefixed with the path
+// p.
+func (v *Validator) mergeKey(p Path, other *Validator, k string) {
+	for _, e := range other.fieldErrors(k) {
+		v.appendPath(p.join(e.Path...), e.Code, e.Message, e.Params)
 	}
 }
 
 // Strings representation of all errors, or a blank string if there are none.
+//
+// Warnings are listed after the errors, prefixed with "warning: ".
 func (v *Validator) String() string {
-	if !v.HasErrors() {
+	var (
+		keys  = v.keys()
+		w     = v.warnings()
+		wkeys []string
+	)
+	if w != nil {
+		wkeys = w.keys()
+	}
+	if len(keys) == 0 && len(wkeys) == 0 {
 		return ""
 	}
 
-	// Make sure the order is always the same.
-	keys := make([]string, len(v.Errors))
-	i := 0
-	for k := range v.Errors {
-		keys[i] = k
-		i++
-	}
-	sort.Strings(keys)
-
-	var b strings.Builder
-	for _, k := range keys {
-		if k != "" {
-			b.WriteString(k)
-			b.WriteString(": ")
-		}
-		b.WriteString(strings.Join(v.Errors[k], ", "))
-		b.WriteByte('.')
-		if len(keys) > 1 {
-			b.WriteByte('\n')
+	var (
+		b     strings.Builder
+		multi = len(keys)+len(wkeys) > 1
+		line  = func(k, prefix string, msgs []string) {
+			if k != "" {
+				b.WriteString(k)
+				b.WriteString(": ")
+			}
+			b.WriteString(prefix)
+			b.WriteString(strings.Join(msgs, ", "))
+			b.WriteByte('.')
+			if multi {
+				b.WriteByte('\n')
+			}
 		}
+	)
+	for _, k := range keys {
+		line(k, "", v.Errors[k])
+	}
+	for _, k := range wkeys {
+		line(k, "warning: ", w.Errors[k])
 	}
 	return b.String()
 }
 
 // HTML representation of all errors, or a blank string if there are none.
+//
+// Warnings are listed after the errors, with the "warning" class.
 func (v *Validator) HTML() template.HTML {
-	if !v.HasErrors() {
+	if !v.HasErrors() && !v.HasWarnings() {
 		return ""
 	}
-
-	// Make sure the order is always the same.
-	keys := make([]string, len(v.Errors))
-	i := 0
-	for k := range v.Errors {
-		keys[i] = k
-		i++
-	}
-	sort.Strings(keys)
-
-	var b strings.Builder
-	b.WriteString("<ul class='zvalidate'>\n")
-	for _, k := range keys {
-		b.WriteString("<li>")
-		if k != "" {
-			b.WriteString(fmt.Sprintf("<strong>%s</strong>: ", template.HTMLEscapeString(k)))
-		}
-		b.WriteString(fmt.Sprintf("%s.</li>\n", template.HTMLEscapeString(strings.Join(v.Errors[k], ", "))))
-	}
-
-	b.WriteString("</ul>\n")
-	return template.HTML(b.String())
+	return execTemplate(v.templates().HTML, DefaultTemplates.HTML, v.templateFields())
 }
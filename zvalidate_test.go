@@ -1,6 +1,7 @@
 package zvalidate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
@@ -129,6 +130,12 @@ func TestSub(t *testing.T) {
 		ls1.Sub("lsub2", "holiday", ls2)
 		v.Sub("lsub1", "", ls1)
 
+		// Errors without a key in the sub-Validator.
+		nokey := New()
+		nokey.Append("", "invalid address")
+		v.Sub("address", "", nokey)
+		v.Sub("items", "1", nokey)
+
 		want := fmt.Sprintf("%+v", map[string][]string{
 			"lsub1.lsub2[holiday].err": []string{"very sub"},
 			"sub1.sub2.err":            []string{"very sub"},
@@ -139,6 +146,8 @@ func TestSub(t *testing.T) {
 			"addresses[office].city":   []string{"must be set"},
 			"other":                    []string{"oh noes"},
 			"emails[office]":           []string{"not an email"},
+			"address":                  []string{"invalid address"},
+			"items[1]":                 []string{"invalid address"},
 		})
 
 		if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
@@ -153,21 +162,21 @@ func TestString(t *testing.T) {
 		want string
 	}{
 		{Validator{}, ""},
-		{Validator{map[string][]string{}, DefaultMessages}, ""},
+		{Validator{Errors: map[string][]string{}, msg: DefaultMessages}, ""},
 
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no"},
-		}, DefaultMessages}, "k: oh no."},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "k: oh no."},
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more"},
-		}, DefaultMessages}, "k: oh no, more."},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "k: oh no, more."},
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more", "even more"},
-		}, DefaultMessages}, "k: oh no, more, even more."},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "k: oh no, more, even more."},
+		{Validator{Errors: map[string][]string{
 			"k":  {"oh no", "more", "even more"},
 			"k2": {"asd"},
-		}, DefaultMessages}, "k: oh no, more, even more.\nk2: asd.\n"},
+		}, msg: DefaultMessages}, "k: oh no, more, even more.\nk2: asd.\n"},
 	}
 
 	for i, tt := range tests {
@@ -202,21 +211,21 @@ func TestHTML(t *testing.T) {
 		want template.HTML
 	}{
 		{Validator{}, ""},
-		{Validator{map[string][]string{}, DefaultMessages}, ""},
+		{Validator{Errors: map[string][]string{}, msg: DefaultMessages}, ""},
 
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no"},
-		}, DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no.</li>\n</ul>\n"},
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more"},
-		}, DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more.</li>\n</ul>\n"},
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more", "even more"},
-		}, DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		}, msg: DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n</ul>\n"},
+		{Validator{Errors: map[string][]string{
 			"k":  {"oh no", "more", "even more"},
 			"k2": {"asd"},
-		}, DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n<li><strong>k2</strong>: asd.</li>\n</ul>\n"},
+		}, msg: DefaultMessages}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n<li><strong>k2</strong>: asd.</li>\n</ul>\n"},
 	}
 
 	for i, tt := range tests {
@@ -314,3 +323,171 @@ func TestPop(t *testing.T) {
 		t.Errorf("v.HasErrors(): %#v", v.Errors)
 	}
 }
+
+func TestOrdered(t *testing.T) {
+	v := New()
+	v.Required("name", "")
+	v.Required("zip", "")
+	v.Email("email", "x")
+	v.Append("name", "more")
+
+	addr := New()
+	addr.Required("street", "")
+	addr.Required("city", "")
+	v.Sub("address", "", addr)
+
+	{ // Sorted by default.
+		want := "address.city: must be set.\naddress.street: must be set.\nemail: must be a valid email address.\nname: must be set, more.\nzip: must be set.\n"
+		if d := ztest.Diff(v.String(), want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	v.Ordered(true)
+
+	{
+		have := fmt.Sprintf("%q", v.Keys())
+		want := `["name" "zip" "email" "address.street" "address.city"]`
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		want := "name: must be set, more.\nzip: must be set.\nemail: must be a valid email address.\naddress.street: must be set.\naddress.city: must be set.\n"
+		if d := ztest.Diff(v.String(), want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		want := "<ul class='zvalidate'>\n" +
+			"<li><strong>name</strong>: must be set, more.</li>\n" +
+			"<li><strong>zip</strong>: must be set.</li>\n" +
+			"<li><strong>email</strong>: must be a valid email address.</li>\n" +
+			"<li><strong>address.street</strong>: must be set.</li>\n" +
+			"<li><strong>address.city</strong>: must be set.</li>\n" +
+			"</ul>\n"
+		if d := ztest.Diff(string(v.HTML()), want); d != "" {
+			t.Error(d)
+		}
+	}
+	{
+		j, err := v.ErrorJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"errors":{"name":["must be set","more"],"zip":["must be set"],"email":["must be a valid email address"],"address.street":["must be set"],"address.city":["must be set"]}}`
+		if d := ztest.Diff(string(j), want); d != "" {
+			t.Error(d)
+		}
+	}
+
+	{ // Pop and re-add moves it to the end; direct map writes go last.
+		v.Pop("name")
+		v.Append("name", "again")
+		v.Errors["a"] = []string{"direct"}
+
+		have := fmt.Sprintf("%q", v.Keys())
+		want := `["zip" "email" "address.street" "address.city" "name" "a"]`
+		if d := ztest.Diff(have, want); d != "" {
+			t.Error(d)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   Validator
+		want string
+	}{
+		{Validator{}, `{"errors":null}`},
+		{New(), `{"errors":{}}`},
+		{Validator{Errors: map[string][]string{"b": {"x"}, "a": {"y", "z"}}}, `{"errors":{"a":["y","z"],"b":["x"]}}`},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			have, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := ztest.Diff(string(have), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+type dbError struct{ query string }
+
+func (e dbError) Error() string { return "pq: syntax error in " + e.query }
+
+func TestSubCause(t *testing.T) {
+	errDB := dbError{"SELECT"}
+	errOther := errors.New("oh noes")
+
+	v := New()
+	v.Messages(Messages{Cause: func() string { return "something went wrong" }})
+	v.Required("name", "")
+	v.Sub("email", "", errDB)
+
+	s := New()
+	s.Sub("city", "", errOther)
+	v.Sub("address", "", s)
+
+	want := "address.city: oh noes.\nemail: something went wrong.\nname: must be set.\n"
+	if d := ztest.Diff(v.String(), want); d != "" {
+		t.Error(d)
+	}
+
+	err := v.ErrorOrNil()
+	if As(err) == nil {
+		t.Fatal("not a Validator")
+	}
+	if !errors.Is(err, errOther) {
+		t.Error("errors.Is(errOther) is false")
+	}
+	var dbErr dbError
+	if !errors.As(err, &dbErr) || dbErr.query != "SELECT" {
+		t.Errorf("errors.As(dbError) failed: %#v", dbErr)
+	}
+	if e := New(); errors.Is(e.ErrorOrNil(), errOther) {
+		t.Error("errors.Is() on empty Validator")
+	}
+}
+
+func TestPeek(t *testing.T) {
+	v := New()
+	v.Append("a", "err")
+	v.Append("a", "err2")
+	v.Append("b", "err3")
+	v.Append("c", "err4")
+
+	if have := v.Peek("nonexistent"); have != nil {
+		t.Errorf("not nil: %#v", have)
+	}
+
+	for range 2 {
+		have := fmt.Sprintf("%q", v.Peek("a"))
+		if d := ztest.Diff(have, `["err" "err2"]`); d != "" {
+			t.Error(d)
+		}
+	}
+	if d := ztest.Diff(fmt.Sprintf("%q", v.Remaining()), `["b" "c"]`); d != "" {
+		t.Error(d)
+	}
+	if !v.Failed("a") {
+		t.Error("a was removed")
+	}
+
+	v.Pop("c")
+	if d := ztest.Diff(fmt.Sprintf("%q", v.Remaining()), `["b"]`); d != "" {
+		t.Error(d)
+	}
+
+	// Pop clears the displayed state.
+	v.Pop("a")
+	v.Append("a", "again")
+	if d := ztest.Diff(fmt.Sprintf("%q", v.Remaining()), `["a" "b"]`); d != "" {
+		t.Error(d)
+	}
+}